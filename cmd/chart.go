@@ -31,6 +31,21 @@ var chartCmd = &cobra.Command{
 
 // --- List ---
 
+var chartListColumns = []output.Column[client.Chart]{
+	{Name: "ID", Value: func(ch client.Chart) interface{} { return ch.ID }},
+	{Name: "TITLE", Value: func(ch client.Chart) interface{} { return ch.Title }},
+	{Name: "URL", Flex: true, Value: func(ch client.Chart) interface{} { return ch.URL }},
+	{Name: "JOB", Value: func(ch client.Chart) interface{} {
+		if ch.Job == nil {
+			return nil
+		}
+		return ch.Job.Name
+	}},
+	{Name: "DESCRIPTION", Flex: true, Value: func(ch client.Chart) interface{} { return ch.Description }},
+	{Name: "SIZE", Wide: true, Value: func(ch client.Chart) interface{} { return fmt.Sprintf("%dx%d", ch.Width, ch.Height) }},
+	{Name: "POSITION", Wide: true, Value: func(ch client.Chart) interface{} { return fmt.Sprintf("(%d, %d)", ch.X, ch.Y) }},
+}
+
 var chartListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List charts",
@@ -40,33 +55,13 @@ var chartListCmd = &cobra.Command{
 			return err
 		}
 
-		charts, err := c.ListCharts()
+		charts, err := c.ListCharts(listPage())
 		if err != nil {
 			return err
 		}
+		charts = trimPage(charts)
 
-		if output.JSONMode {
-			output.PrintJSON(charts)
-			return nil
-		}
-
-		headers := []string{"ID", "TITLE", "URL", "JOB", "DESCRIPTION"}
-		var rows [][]string
-		for _, ch := range charts {
-			job := ""
-			if ch.Job != nil {
-				job = ch.Job.Name
-			}
-			rows = append(rows, []string{
-				strconv.Itoa(ch.ID),
-				ch.Title,
-				truncate(ch.URL, 40),
-				job,
-				truncate(ch.Description, 40),
-			})
-		}
-		output.Table(headers, rows)
-		return nil
+		return printList(charts, chartListColumns)
 	},
 }
 
@@ -258,6 +253,7 @@ func init() {
 	rootCmd.AddCommand(chartCmd)
 
 	// List
+	addListFlags(chartListCmd)
 	chartCmd.AddCommand(chartListCmd)
 
 	// Info
@@ -0,0 +1,338 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/config"
+	"github.com/MagicLex/hopsworks-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configViewShowOrigin    bool
+	configSetProjectID      int
+	configSetFeatureStoreID int
+	configSetClientCert     string
+	configSetClientKey      string
+	configSetCredHelper     string
+	configSetUse            bool
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage CLI config and contexts (~/.hops/config)",
+}
+
+// contextInfo is the JSON shape of one context in get-contexts.
+type contextInfo struct {
+	Name           string `json:"name"`
+	Current        bool   `json:"current"`
+	Host           string `json:"host"`
+	Project        string `json:"project,omitempty"`
+	ProjectID      int    `json:"projectId,omitempty"`
+	FeatureStoreID int    `json:"featureStoreId,omitempty"`
+}
+
+var configGetContextsCmd = &cobra.Command{
+	Use:   "get-contexts",
+	Short: "List config contexts",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := config.LoadFile()
+		if err != nil {
+			return err
+		}
+
+		var infos []contextInfo
+		for _, name := range file.Names() {
+			ctx := file.Contexts[name]
+			infos = append(infos, contextInfo{
+				Name:           name,
+				Current:        name == cfg.Context,
+				Host:           ctx.Host,
+				Project:        ctx.Project,
+				ProjectID:      ctx.ProjectID,
+				FeatureStoreID: ctx.FeatureStoreID,
+			})
+		}
+
+		if output.Structured() {
+			output.PrintJSON(infos)
+			return nil
+		}
+
+		headers := []string{"CURRENT", "NAME", "HOST", "PROJECT", "FS"}
+		var rows []output.Row
+		for _, info := range infos {
+			current := ""
+			if info.Current {
+				current = "*"
+			}
+			var fs interface{}
+			if info.FeatureStoreID > 0 {
+				fs = info.FeatureStoreID
+			}
+			rows = append(rows, output.Row{current, info.Name, info.Host, info.Project, fs})
+		}
+		output.Table(headers, rows)
+		return nil
+	},
+}
+
+var configUseContextCmd = &cobra.Command{
+	Use:   "use-context <name>",
+	Short: "Switch the current context",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := config.LoadFile()
+		if err != nil {
+			return err
+		}
+		name := args[0]
+		if _, ok := file.Contexts[name]; !ok {
+			return fmt.Errorf("context '%s' not found. Run 'hops config get-contexts' to list contexts", name)
+		}
+		file.CurrentContext = name
+		if err := file.Save(); err != nil {
+			return fmt.Errorf("save config: %w", err)
+		}
+		output.Success("Switched to context '%s'", name)
+		return nil
+	},
+}
+
+var configSetContextCmd = &cobra.Command{
+	Use:   "set-context <name>",
+	Short: "Create or update a context",
+	Long: `Create or update a context. Only the values given are changed.
+
+Examples:
+  hops config set-context staging --host https://staging.hops.example.com --api-key <key>
+  hops config set-context prod --project fraud --use
+  hops config set-context dev --ca-cert ~/certs/dev-ca.pem
+  hops config set-context prod --credential-helper "hops-keychain"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := config.LoadFile()
+		if err != nil {
+			return err
+		}
+		name := args[0]
+		ctx, exists := file.Contexts[name]
+		if !exists {
+			ctx = &config.Config{}
+			file.Contexts[name] = ctx
+		}
+
+		// --host, --api-key, --project and --ca-cert are the global flags
+		flags := cmd.Flags()
+		if flags.Changed("host") {
+			ctx.Host = normalizeHost(flagHost)
+		}
+		if flags.Changed("api-key") {
+			ctx.APIKey = flagAPIKey
+		}
+		if flags.Changed("project") {
+			// Project IDs belong to the old project; re-resolved on 'hops project use'
+			ctx.Project = flagProject
+			ctx.ProjectID = 0
+			ctx.FeatureStoreID = 0
+		}
+		if flags.Changed("ca-cert") {
+			ctx.CABundle = flagCACert
+		}
+		if flags.Changed("project-id") {
+			ctx.ProjectID = configSetProjectID
+		}
+		if flags.Changed("feature-store-id") {
+			ctx.FeatureStoreID = configSetFeatureStoreID
+		}
+		if flags.Changed("client-cert") {
+			ctx.ClientCert = configSetClientCert
+		}
+		if flags.Changed("client-key") {
+			ctx.ClientKey = configSetClientKey
+		}
+		if flags.Changed("credential-helper") {
+			ctx.CredentialHelper = configSetCredHelper
+		}
+		if configSetUse || file.CurrentContext == "" {
+			file.CurrentContext = name
+		}
+
+		if err := file.Save(); err != nil {
+			return fmt.Errorf("save config: %w", err)
+		}
+		if exists {
+			output.Success("Updated context '%s'", name)
+		} else {
+			output.Success("Created context '%s'", name)
+		}
+		if file.CurrentContext == name {
+			output.Info("Current context: %s", name)
+		}
+		return nil
+	},
+}
+
+var configDeleteContextCmd = &cobra.Command{
+	Use:   "delete-context <name>",
+	Short: "Delete a context",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := config.LoadFile()
+		if err != nil {
+			return err
+		}
+		name := args[0]
+		if _, ok := file.Contexts[name]; !ok {
+			return fmt.Errorf("context '%s' not found", name)
+		}
+		delete(file.Contexts, name)
+		if file.CurrentContext == name {
+			file.CurrentContext = ""
+		}
+		if err := file.Save(); err != nil {
+			return fmt.Errorf("save config: %w", err)
+		}
+		output.Success("Deleted context '%s'", name)
+		if file.CurrentContext == "" && len(file.Contexts) > 0 {
+			output.Info("No current context. Run 'hops config use-context <name>'")
+		}
+		return nil
+	},
+}
+
+// configValue is one effective setting in 'config view'.
+type configValue struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Origin string `json:"origin,omitempty"`
+}
+
+var configViewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Show the effective config",
+	Long: `Show the effective config after applying flags, env vars, .hops.yaml and ~/.hops/config.
+
+Precedence (highest first): flags, env vars, .hops.yaml (nearest parent directory),
+~/.hops/config (current context).
+
+Examples:
+  hops config view
+  hops config view --show-origin`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiKey := ""
+		if cfg.APIKey != "" {
+			apiKey = "****" + cfg.APIKey[max(len(cfg.APIKey)-4, 0):]
+		}
+		values := []configValue{
+			{Key: "context", Value: cfg.Context},
+			{Key: "mode", Value: cfg.Mode()},
+			{Key: "host", Value: cfg.Host},
+			{Key: "api_key", Value: apiKey},
+			{Key: "credential_helper", Value: cfg.CredentialHelper},
+			{Key: "project", Value: cfg.Project},
+			{Key: "project_id", Value: intOrEmpty(cfg.ProjectID)},
+			{Key: "feature_store", Value: cfg.FeatureStore},
+			{Key: "feature_store_id", Value: intOrEmpty(cfg.FeatureStoreID)},
+			{Key: "ca_bundle", Value: cfg.CABundle},
+			{Key: "client_cert", Value: cfg.ClientCert},
+			{Key: "client_key", Value: cfg.ClientKey},
+		}
+		for i := range values {
+			values[i].Origin = cfg.Origins[values[i].Key]
+		}
+		if cfg.Internal {
+			values[1].Origin = "REST_ENDPOINT + SECRETS_DIR"
+		}
+		if cfg.Pinned != nil {
+			for _, kind := range []string{"fg", "fv", "model"} {
+				names := make([]string, 0, len(cfg.Pinned.Versions[kind]))
+				for name := range cfg.Pinned.Versions[kind] {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				for _, name := range names {
+					values = append(values, configValue{
+						Key:    fmt.Sprintf("versions.%s.%s", kind, name),
+						Value:  strconv.Itoa(cfg.Pinned.Versions[kind][name]),
+						Origin: cfg.Pinned.Path,
+					})
+				}
+			}
+		}
+
+		if output.JSONMode {
+			if !configViewShowOrigin {
+				for i := range values {
+					values[i].Origin = ""
+				}
+			}
+			output.PrintJSON(values)
+			return nil
+		}
+
+		headers := []string{"KEY", "VALUE"}
+		if configViewShowOrigin {
+			headers = append(headers, "ORIGIN")
+		}
+		var rows []output.Row
+		for _, v := range values {
+			row := output.Row{v.Key, v.Value}
+			if configViewShowOrigin {
+				row = append(row, v.Origin)
+			}
+			rows = append(rows, row)
+		}
+		output.Table(headers, rows)
+		return nil
+	},
+}
+
+func intOrEmpty(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// applyPinnedVersion fills --version from .hops.yaml when a fg/fv/model
+// command was run without it. Create/delete/register keep their own defaults.
+func applyPinnedVersion(cmd *cobra.Command, args []string) {
+	if cfg.Pinned == nil || len(args) == 0 || cmd.Parent() == nil {
+		return
+	}
+	switch cmd.Name() {
+	case "create", "delete", "register":
+		return
+	}
+	kind := cmd.Parent().Name()
+	flag := cmd.Flags().Lookup("version")
+	if flag == nil || flag.Changed {
+		return
+	}
+	if v := cfg.Pinned.Version(kind, args[0]); v > 0 {
+		cmd.Flags().Set("version", strconv.Itoa(v))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+
+	configViewCmd.Flags().BoolVar(&configViewShowOrigin, "show-origin", false, "Show where each value came from")
+	configCmd.AddCommand(configViewCmd)
+	configCmd.AddCommand(configGetContextsCmd)
+	configCmd.AddCommand(configUseContextCmd)
+
+	configSetContextCmd.Flags().IntVar(&configSetProjectID, "project-id", 0, "Project ID")
+	configSetContextCmd.Flags().IntVar(&configSetFeatureStoreID, "feature-store-id", 0, "Feature store ID")
+	configSetContextCmd.Flags().StringVar(&configSetClientCert, "client-cert", "", "PEM client certificate for mTLS")
+	configSetContextCmd.Flags().StringVar(&configSetClientKey, "client-key", "", "PEM client key for mTLS")
+	configSetContextCmd.Flags().StringVar(&configSetCredHelper, "credential-helper", "", "Executable that prints the API key (see README)")
+	configSetContextCmd.Flags().BoolVar(&configSetUse, "use", false, "Also make it the current context")
+	configCmd.AddCommand(configSetContextCmd)
+
+	configCmd.AddCommand(configDeleteContextCmd)
+}
@@ -58,6 +58,13 @@ var connectorCmd = &cobra.Command{
 
 // --- List ---
 
+var connectorListColumns = []output.Column[client.StorageConnector]{
+	{Name: "NAME", Value: func(sc client.StorageConnector) interface{} { return sc.Name }},
+	{Name: "TYPE", Value: func(sc client.StorageConnector) interface{} { return sc.StorageConnectorType }},
+	{Name: "DESCRIPTION", Flex: true, Value: func(sc client.StorageConnector) interface{} { return sc.Description }},
+	{Name: "ID", Wide: true, Value: func(sc client.StorageConnector) interface{} { return sc.ID }},
+}
+
 var connectorListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List storage connectors",
@@ -67,27 +74,13 @@ var connectorListCmd = &cobra.Command{
 			return err
 		}
 
-		connectors, err := c.ListStorageConnectors()
+		connectors, err := c.ListStorageConnectors(listPage())
 		if err != nil {
 			return err
 		}
+		connectors = trimPage(connectors)
 
-		if output.JSONMode {
-			output.PrintJSON(connectors)
-			return nil
-		}
-
-		headers := []string{"NAME", "TYPE", "DESCRIPTION"}
-		var rows [][]string
-		for _, sc := range connectors {
-			rows = append(rows, []string{
-				sc.Name,
-				sc.StorageConnectorType,
-				truncate(sc.Description, 50),
-			})
-		}
-		output.Table(headers, rows)
-		return nil
+		return printList(connectors, connectorListColumns)
 	},
 }
 
@@ -177,6 +170,14 @@ var connectorInfoCmd = &cobra.Command{
 
 // --- Test ---
 
+// connectorTestResult is the JSON form of 'hops connector test'.
+type connectorTestResult struct {
+	Status    string   `json:"status"`
+	Connector string   `json:"connector"`
+	Type      string   `json:"type"`
+	Databases []string `json:"databases"`
+}
+
 var connectorTestCmd = &cobra.Command{
 	Use:   "test <name>",
 	Short: "Test a connector by listing databases",
@@ -200,11 +201,11 @@ var connectorTestCmd = &cobra.Command{
 		}
 
 		if output.JSONMode {
-			output.PrintJSON(map[string]interface{}{
-				"status":    "connected",
-				"connector": sc.Name,
-				"type":      sc.StorageConnectorType,
-				"databases": dbs,
+			output.PrintJSON(&connectorTestResult{
+				Status:    "connected",
+				Connector: sc.Name,
+				Type:      sc.StorageConnectorType,
+				Databases: dbs,
 			})
 			return nil
 		}
@@ -234,15 +235,15 @@ var connectorDatabasesCmd = &cobra.Command{
 			return err
 		}
 
-		if output.JSONMode {
-			output.PrintJSON(dbs)
+		if output.Structured() {
+			output.PrintKind("Database", dbs)
 			return nil
 		}
 
 		headers := []string{"DATABASE"}
-		var rows [][]string
+		var rows []output.Row
 		for _, db := range dbs {
-			rows = append(rows, []string{db})
+			rows = append(rows, output.Row{db})
 		}
 		output.Table(headers, rows)
 		return nil
@@ -266,15 +267,15 @@ var connectorTablesCmd = &cobra.Command{
 			return err
 		}
 
-		if output.JSONMode {
+		if output.Structured() {
 			output.PrintJSON(tables)
 			return nil
 		}
 
 		headers := []string{"DATABASE", "SCHEMA", "TABLE"}
-		var rows [][]string
+		var rows []output.Row
 		for _, t := range tables {
-			rows = append(rows, []string{t.Database, t.Group, t.Table})
+			rows = append(rows, output.Row{t.Database, t.Group, t.Table})
 		}
 		output.Table(headers, rows)
 		return nil
@@ -310,8 +311,8 @@ var connectorPreviewCmd = &cobra.Command{
 
 		previewRows := result.PreviewRows()
 
-		if output.JSONMode {
-			output.PrintJSON(previewRows)
+		if output.Structured() {
+			output.PrintKind("Row", previewRows)
 			return nil
 		}
 
@@ -335,9 +336,9 @@ var connectorPreviewCmd = &cobra.Command{
 			}
 		}
 
-		var rows [][]string
+		var rows []output.Row
 		for _, row := range previewRows {
-			var r []string
+			var r output.Row
 			for _, h := range headers {
 				r = append(r, row[h])
 			}
@@ -644,6 +645,7 @@ func init() {
 	rootCmd.AddCommand(connectorCmd)
 
 	// List
+	addListFlags(connectorListCmd)
 	connectorCmd.AddCommand(connectorListCmd)
 
 	// Info
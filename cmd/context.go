@@ -4,10 +4,21 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
 	"github.com/MagicLex/hopsworks-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
 
+// projectContext is the JSON form of 'hops context'.
+type projectContext struct {
+	Project       string                `json:"project"`
+	ProjectID     int                   `json:"project_id"`
+	Host          string                `json:"host"`
+	FeatureGroups []client.FeatureGroup `json:"feature_groups"`
+	FeatureViews  []client.FeatureView  `json:"feature_views"`
+	Jobs          []client.Job          `json:"jobs"`
+}
+
 var contextCmd = &cobra.Command{
 	Use:   "context",
 	Short: "Dump project context (for LLMs)",
@@ -25,7 +36,7 @@ var contextCmd = &cobra.Command{
 		sb.WriteString(fmt.Sprintf("Host: %s | Project ID: %d | Feature Store ID: %d\n\n", cfg.Host, cfg.ProjectID, cfg.FeatureStoreID))
 
 		// Feature Groups
-		fgs, err := c.ListFeatureGroups()
+		fgs, err := c.ListFeatureGroups(client.Page{})
 		if err != nil {
 			sb.WriteString(fmt.Sprintf("Feature Groups: error fetching (%v)\n\n", err))
 		} else {
@@ -57,7 +68,7 @@ var contextCmd = &cobra.Command{
 		}
 
 		// Feature Views
-		fvs, err := c.ListFeatureViews()
+		fvs, err := c.ListFeatureViews(client.Page{})
 		if err != nil {
 			sb.WriteString(fmt.Sprintf("Feature Views: error fetching (%v)\n\n", err))
 		} else {
@@ -82,7 +93,7 @@ var contextCmd = &cobra.Command{
 		}
 
 		// Jobs
-		jobs, err := c.ListJobs()
+		jobs, err := c.ListJobs(client.Page{})
 		if err == nil && len(jobs) > 0 {
 			sb.WriteString(fmt.Sprintf("## Jobs (%d)\n\n", len(jobs)))
 			for _, j := range jobs {
@@ -94,13 +105,13 @@ var contextCmd = &cobra.Command{
 		result := sb.String()
 
 		if output.JSONMode {
-			output.PrintJSON(map[string]interface{}{
-				"project":        cfg.Project,
-				"project_id":     cfg.ProjectID,
-				"host":           cfg.Host,
-				"feature_groups": fgs,
-				"feature_views":  fvs,
-				"jobs":           jobs,
+			output.PrintJSON(&projectContext{
+				Project:       cfg.Project,
+				ProjectID:     cfg.ProjectID,
+				Host:          cfg.Host,
+				FeatureGroups: fgs,
+				FeatureViews:  fvs,
+				Jobs:          jobs,
 			})
 			return nil
 		}
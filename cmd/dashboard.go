@@ -29,6 +29,12 @@ var dashboardCmd = &cobra.Command{
 
 // --- List ---
 
+var dashboardListColumns = []output.Column[client.Dashboard]{
+	{Name: "ID", Value: func(d client.Dashboard) interface{} { return d.ID }},
+	{Name: "NAME", Value: func(d client.Dashboard) interface{} { return d.Name }},
+	{Name: "CHARTS", Value: func(d client.Dashboard) interface{} { return len(d.Charts) }},
+}
+
 var dashboardListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List dashboards",
@@ -38,27 +44,13 @@ var dashboardListCmd = &cobra.Command{
 			return err
 		}
 
-		dashboards, err := c.ListDashboards()
+		dashboards, err := c.ListDashboards(listPage())
 		if err != nil {
 			return err
 		}
+		dashboards = trimPage(dashboards)
 
-		if output.JSONMode {
-			output.PrintJSON(dashboards)
-			return nil
-		}
-
-		headers := []string{"ID", "NAME", "CHARTS"}
-		var rows [][]string
-		for _, d := range dashboards {
-			rows = append(rows, []string{
-				strconv.Itoa(d.ID),
-				d.Name,
-				strconv.Itoa(len(d.Charts)),
-			})
-		}
-		output.Table(headers, rows)
-		return nil
+		return printList(dashboards, dashboardListColumns)
 	},
 }
 
@@ -95,12 +87,12 @@ var dashboardInfoCmd = &cobra.Command{
 		if len(d.Charts) > 0 {
 			fmt.Println()
 			headers := []string{"ID", "TITLE", "SIZE", "POSITION"}
-			var rows [][]string
+			var rows []output.Row
 			for _, ch := range d.Charts {
 				size := fmt.Sprintf("%dx%d", ch.Width, ch.Height)
 				pos := fmt.Sprintf("(%d, %d)", ch.X, ch.Y)
-				rows = append(rows, []string{
-					strconv.Itoa(ch.ID),
+				rows = append(rows, output.Row{
+					ch.ID,
 					ch.Title,
 					size,
 					pos,
@@ -301,6 +293,7 @@ func init() {
 	rootCmd.AddCommand(dashboardCmd)
 
 	// List
+	addListFlags(dashboardListCmd)
 	dashboardCmd.AddCommand(dashboardListCmd)
 
 	// Info
@@ -3,6 +3,7 @@ package cmd
 import (
 	"strings"
 
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
 	"github.com/MagicLex/hopsworks-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -12,6 +13,18 @@ var datasetCmd = &cobra.Command{
 	Short: "Browse project files",
 }
 
+var datasetListColumns = []output.Column[client.DatasetFile]{
+	{Name: "NAME", Value: func(f client.DatasetFile) interface{} { return f.Name }},
+	{Name: "TYPE", Value: func(f client.DatasetFile) interface{} {
+		if f.DatasetType == "" {
+			return "file"
+		}
+		return strings.ToLower(f.DatasetType)
+	}},
+	{Name: "SIZE", Wide: true, Value: func(f client.DatasetFile) interface{} { return f.Size }},
+	{Name: "DESCRIPTION", Wide: true, Flex: true, Value: func(f client.DatasetFile) interface{} { return f.Description }},
+}
+
 var datasetListCmd = &cobra.Command{
 	Use:   "list [path]",
 	Short: "List files in a dataset path",
@@ -26,27 +39,13 @@ var datasetListCmd = &cobra.Command{
 			return err
 		}
 
-		files, err := c.ListDatasets(path)
+		files, err := c.ListDatasets(path, listPage())
 		if err != nil {
 			return err
 		}
+		files = trimPage(files)
 
-		if output.JSONMode {
-			output.PrintJSON(files)
-			return nil
-		}
-
-		headers := []string{"NAME", "TYPE"}
-		var rows [][]string
-		for _, f := range files {
-			t := strings.ToLower(f.DatasetType)
-			if t == "" {
-				t = "file"
-			}
-			rows = append(rows, []string{f.Name, t})
-		}
-		output.Table(headers, rows)
-		return nil
+		return printList(files, datasetListColumns)
 	},
 }
 
@@ -71,6 +70,7 @@ var datasetMkdirCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(datasetCmd)
+	addListFlags(datasetListCmd)
 	datasetCmd.AddCommand(datasetListCmd)
 	datasetCmd.AddCommand(datasetMkdirCmd)
 }
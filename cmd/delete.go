@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
+	"github.com/MagicLex/hopsworks-cli/pkg/output"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Shared by fg, fv and td delete
+var (
+	deleteForce       bool
+	deleteDryRun      bool
+	deleteAllVersions bool
+)
+
+// addDeleteFlags registers --force, --dry-run and --all-versions on a delete
+// command guarded by confirmDelete.
+func addDeleteFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&deleteForce, "force", false, "Delete even if other artifacts depend on it, without asking")
+	cmd.Flags().BoolVar(&deleteDryRun, "dry-run", false, "Show what would be deleted and what depends on it, then stop")
+	cmd.Flags().BoolVar(&deleteAllVersions, "all-versions", false, "Delete every version")
+}
+
+// artifactRef names a feature store or registry artifact.
+type artifactRef struct {
+	Kind    string `json:"kind"` // featureGroup, featureView, trainingDataset, model, deployment
+	Name    string `json:"name"`
+	Version int    `json:"version,omitempty"`
+	Via     string `json:"via,omitempty"` // the artifact it depends on
+}
+
+var artifactKindLabels = map[string]string{
+	"featureGroup":    "feature group",
+	"featureView":     "feature view",
+	"trainingDataset": "training dataset",
+	"model":           "model",
+	"deployment":      "deployment",
+}
+
+func (a artifactRef) String() string {
+	s := fmt.Sprintf("%s '%s'", artifactKindLabels[a.Kind], a.Name)
+	if a.Version > 0 {
+		s += fmt.Sprintf(" v%d", a.Version)
+	}
+	return s
+}
+
+func (a artifactRef) key() string {
+	return fmt.Sprintf("%s/%s/%d", a.Kind, a.Name, a.Version)
+}
+
+// deleteResult is the JSON form of fg, fv and td delete.
+type deleteResult struct {
+	Targets    []artifactRef `json:"targets"`
+	Dependents []artifactRef `json:"dependents"`
+	DryRun     bool          `json:"dryRun"`
+	Failed     []artifactRef `json:"failed,omitempty"` // targets whose delete failed
+}
+
+// dependencyScan collects what depends on the artifacts about to be deleted:
+// feature views and derived feature groups (provenance), training datasets,
+// models trained on them and the deployments serving those models.
+type dependencyScan struct {
+	c           *client.Client
+	result      deleteResult
+	seen        map[string]bool
+	deployments []client.Deployment
+	errs        []error
+}
+
+func newDependencyScan(c *client.Client) *dependencyScan {
+	return &dependencyScan{c: c, seen: map[string]bool{}, result: deleteResult{Dependents: []artifactRef{}}}
+}
+
+// target marks ref as being deleted, so it doesn't count as a dependent.
+func (s *dependencyScan) target(ref artifactRef) {
+	s.seen[ref.key()] = true
+	s.result.Targets = append(s.result.Targets, ref)
+}
+
+// add records a dependent; it reports false if it was already recorded.
+func (s *dependencyScan) add(ref artifactRef) bool {
+	if s.seen[ref.key()] {
+		return false
+	}
+	s.seen[ref.key()] = true
+	s.result.Dependents = append(s.result.Dependents, ref)
+	return true
+}
+
+func (s *dependencyScan) fail(what string, err error) {
+	s.errs = append(s.errs, fmt.Errorf("%s: %w", what, err))
+}
+
+func (s *dependencyScan) featureGroup(fg *client.FeatureGroup) {
+	ref := artifactRef{Kind: "featureGroup", Name: fg.Name, Version: fg.Version}
+	prov, err := s.c.GetFeatureGroupProvenance(fg.ID, 0, 1)
+	if err != nil {
+		s.fail("provenance of "+ref.String(), err)
+		return
+	}
+	for _, link := range prov.Downstream {
+		n := link.Node
+		if n.Deleted {
+			continue
+		}
+		switch n.ArtifactType {
+		case client.ArtifactFeatureView:
+			fv := artifactRef{Kind: "featureView", Name: n.Artifact.Name, Version: n.Artifact.Version, Via: ref.String()}
+			if s.add(fv) {
+				s.featureView(fv.Name, fv.Version)
+			}
+		default:
+			// derived and external feature groups come in as their own types
+			if strings.Contains(n.ArtifactType, "FEATURE_GROUP") {
+				s.add(artifactRef{Kind: "featureGroup", Name: n.Artifact.Name, Version: n.Artifact.Version, Via: ref.String()})
+			}
+		}
+	}
+}
+
+func (s *dependencyScan) featureView(name string, version int) {
+	ref := artifactRef{Kind: "featureView", Name: name, Version: version}
+	tds, err := s.c.ListTrainingDatasets(name, version, client.Page{})
+	if err != nil {
+		s.fail("training datasets of "+ref.String(), err)
+	}
+	for _, td := range tds {
+		s.add(artifactRef{Kind: "trainingDataset", Name: tdName(td, name, version), Version: td.Version, Via: ref.String()})
+	}
+	s.models(name, version, 0, ref)
+}
+
+func (s *dependencyScan) trainingDataset(fvName string, fvVersion int, td client.TrainingDataset) {
+	s.models(fvName, fvVersion, td.Version, artifactRef{Kind: "trainingDataset", Name: tdName(td, fvName, fvVersion), Version: td.Version})
+}
+
+// tdName is the backend's name for a training dataset: <fv-name>_<fv-version>.
+func tdName(td client.TrainingDataset, fvName string, fvVersion int) string {
+	if td.Name != "" {
+		return td.Name
+	}
+	return fmt.Sprintf("%s_%d", fvName, fvVersion)
+}
+
+// models adds the models trained on a feature view, or on one of its
+// training datasets when tdVersion > 0, and their deployments.
+func (s *dependencyScan) models(fvName string, fvVersion, tdVersion int, via artifactRef) {
+	prov, err := s.c.GetFeatureViewProvenance(fvName, fvVersion, tdVersion, 0, 1)
+	if err != nil {
+		s.fail("models of "+via.String(), err)
+		return
+	}
+	for _, link := range prov.Downstream {
+		n := link.Node
+		if n.Deleted || n.ArtifactType != client.ArtifactModel {
+			continue
+		}
+		model := artifactRef{Kind: "model", Name: n.Artifact.Name, Version: n.Artifact.Version, Via: via.String()}
+		if s.add(model) {
+			s.deploymentsOf(model)
+		}
+	}
+}
+
+func (s *dependencyScan) deploymentsOf(model artifactRef) {
+	if s.deployments == nil {
+		deps, err := s.c.ListDeployments(client.Page{})
+		if err != nil {
+			s.fail("deployments", err)
+			return
+		}
+		s.deployments = deps
+	}
+	for _, d := range s.deployments {
+		if d.ModelName == model.Name && d.ModelVersion == model.Version {
+			s.add(artifactRef{Kind: "deployment", Name: d.Name, Via: model.String()})
+		}
+	}
+}
+
+// confirmDelete shows the scan and decides whether the delete goes ahead. A
+// dry run stops here; dependents need --force, or a yes at the prompt when
+// stdin is a terminal. A scan that failed also needs --force.
+func confirmDelete(s *dependencyScan) (bool, error) {
+	r := &s.result
+	r.DryRun = deleteDryRun
+
+	if err := errors.Join(s.errs...); err != nil {
+		if !deleteForce {
+			return false, fmt.Errorf("check dependents: %w (--force deletes without checking)", err)
+		}
+		output.Warn("could not check all dependents: %v", err)
+	}
+
+	if deleteDryRun {
+		if output.JSONMode {
+			output.PrintJSON(r)
+			return false, nil
+		}
+		for _, t := range r.Targets {
+			output.Info("Would delete %s", t)
+		}
+		printDependents(r.Dependents)
+		return false, nil
+	}
+
+	if len(r.Dependents) == 0 || deleteForce {
+		return true, nil
+	}
+
+	if output.JSONMode {
+		return false, fmt.Errorf("%d artifact(s) depend on %s; --dry-run lists them, --force deletes anyway",
+			len(r.Dependents), describeTargets(r.Targets))
+	}
+	printDependents(r.Dependents)
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, fmt.Errorf("other artifacts depend on %s; pass --force to delete anyway", describeTargets(r.Targets))
+	}
+	fmt.Fprintf(os.Stderr, "\nDelete %s anyway? [y/N] ", describeTargets(r.Targets))
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, fmt.Errorf("delete cancelled")
+}
+
+func describeTargets(targets []artifactRef) string {
+	if len(targets) == 1 {
+		return targets[0].String()
+	}
+	return fmt.Sprintf("%d %ss", len(targets), artifactKindLabels[targets[0].Kind])
+}
+
+func printDependents(deps []artifactRef) {
+	if len(deps) == 0 {
+		output.Info("No dependents")
+		return
+	}
+	output.Info("%d dependent(s):", len(deps))
+	rows := make([]output.Row, len(deps))
+	for i, d := range deps {
+		var version interface{}
+		if d.Version > 0 {
+			version = d.Version
+		}
+		rows[i] = output.Row{artifactKindLabels[d.Kind], d.Name, version, d.Via}
+	}
+	output.Table([]string{"KIND", "NAME", "VERSION", "VIA"}, rows)
+}
+
+// deleteTargets calls del for each target in scan order, carrying on past
+// failures, then reports which targets were deleted and which weren't.
+func deleteTargets(s *dependencyScan, del func(i int) error) error {
+	r := &s.result
+	deleted := []artifactRef{}
+	var errs []error
+	for i, t := range r.Targets {
+		if err := del(i); err != nil {
+			r.Failed = append(r.Failed, t)
+			errs = append(errs, fmt.Errorf("delete %s: %w", t, err))
+			continue
+		}
+		deleted = append(deleted, t)
+	}
+	r.Targets = deleted
+
+	if output.JSONMode {
+		output.PrintJSON(r)
+	} else {
+		for _, t := range r.Targets {
+			output.Success("Deleted %s", t)
+		}
+	}
+	if len(errs) > 0 && len(deleted) > 0 {
+		return fmt.Errorf("deleted %d of %d: %w", len(deleted), len(deleted)+len(r.Failed), errors.Join(errs...))
+	}
+	return errors.Join(errs...)
+}
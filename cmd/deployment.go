@@ -3,7 +3,6 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/MagicLex/hopsworks-cli/pkg/client"
@@ -27,6 +26,18 @@ var deploymentCmd = &cobra.Command{
 	Short:   "Manage model deployments",
 }
 
+var deployListColumns = []output.Column[client.Deployment]{
+	{Name: "ID", Value: func(d client.Deployment) interface{} { return d.ID }},
+	{Name: "NAME", Value: func(d client.Deployment) interface{} { return d.Name }},
+	{Name: "MODEL", Value: func(d client.Deployment) interface{} { return d.ModelName }},
+	{Name: "VERSION", Value: func(d client.Deployment) interface{} { return d.ModelVersion }},
+	{Name: "STATUS", Value: func(d client.Deployment) interface{} { return d.Status }},
+	{Name: "INSTANCES", Value: func(d client.Deployment) interface{} { return d.RequestedInstances }},
+	{Name: "SERVER", Wide: true, Value: func(d client.Deployment) interface{} { return d.ModelServer }},
+	{Name: "CREATOR", Wide: true, Value: func(d client.Deployment) interface{} { return d.Creator }},
+	{Name: "CREATED", Wide: true, Value: func(d client.Deployment) interface{} { return apiTime(d.Created) }},
+}
+
 var deployListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List deployments",
@@ -36,35 +47,17 @@ var deployListCmd = &cobra.Command{
 			return err
 		}
 
-		deployments, err := c.ListDeployments()
+		deployments, err := c.ListDeployments(listPage())
 		if err != nil {
 			return err
 		}
+		deployments = trimPage(deployments)
 
-		if output.JSONMode {
-			output.PrintJSON(deployments)
-			return nil
-		}
-
-		if len(deployments) == 0 {
+		if len(deployments) == 0 && !output.JSONMode {
 			output.Info("No deployments")
 			return nil
 		}
-
-		headers := []string{"ID", "NAME", "MODEL", "VERSION", "STATUS", "INSTANCES"}
-		var rows [][]string
-		for _, d := range deployments {
-			rows = append(rows, []string{
-				strconv.Itoa(d.ID),
-				d.Name,
-				d.ModelName,
-				strconv.Itoa(d.ModelVersion),
-				d.Status,
-				strconv.Itoa(d.RequestedInstances),
-			})
-		}
-		output.Table(headers, rows)
-		return nil
+		return printList(deployments, deployListColumns)
 	},
 }
 
@@ -276,7 +269,9 @@ Examples:
 			return err
 		}
 
-		if output.JSONMode {
+		if output.Structured() && json.Valid(result) {
+			output.PrintKind("Prediction", json.RawMessage(result))
+		} else if output.JSONMode {
 			fmt.Println(string(result))
 		} else {
 			// Pretty-print
@@ -354,6 +349,7 @@ func init() {
 	deployLogsCmd.Flags().IntVar(&deployTail, "tail", 50, "Number of log lines")
 	deployLogsCmd.Flags().StringVar(&deployComponent, "component", "predictor", "Log component (predictor, transformer)")
 
+	addListFlags(deployListCmd)
 	deploymentCmd.AddCommand(deployListCmd)
 	deploymentCmd.AddCommand(deployInfoCmd)
 	deploymentCmd.AddCommand(deployCreateCmd)
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
+	"github.com/MagicLex/hopsworks-cli/pkg/config"
+	"github.com/MagicLex/hopsworks-cli/pkg/output"
+)
+
+// Exit codes. Scripts and LLM agents can branch on these without parsing messages.
+//
+//	0    success
+//	1    generic error
+//	2    usage / validation error (bad flags or args, API 400/422)
+//	3    authentication / authorization failure (API 401/403)
+//	4    resource not found (API 404)
+//	5    conflict, resource already exists (API 409)
+//	6    server error (API 5xx)
+//	7    timeout (request deadline exceeded)
+//	130  interrupted (Ctrl-C)
+const (
+	ExitOK          = 0
+	ExitError       = 1
+	ExitUsage       = 2
+	ExitAuth        = 3
+	ExitNotFound    = 4
+	ExitConflict    = 5
+	ExitServer      = 6
+	ExitTimeout     = 7
+	ExitInterrupted = 130
+)
+
+// usageError marks errors raised by cobra before a command ran (flags, args, unknown command).
+type usageError struct{ err error }
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+// errorKind classifies err into an exit code and a stable machine-readable kind.
+func errorKind(err error) (int, string) {
+	var uerr *usageError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &uerr):
+		return ExitUsage, "usage"
+	case errors.Is(err, context.Canceled):
+		return ExitInterrupted, "interrupted"
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return ExitTimeout, "timeout"
+	case errors.Is(err, config.ErrTokenExpired), client.IsUnauthorized(err):
+		return ExitAuth, "auth"
+	case client.IsNotFound(err):
+		return ExitNotFound, "not_found"
+	case client.IsConflict(err):
+		return ExitConflict, "conflict"
+	case client.IsBadRequest(err):
+		return ExitUsage, "validation"
+	case client.IsServerError(err):
+		return ExitServer, "server"
+	}
+	return ExitError, "error"
+}
+
+// jsonError is the structured error written to stderr in --json mode.
+type jsonError struct {
+	Error struct {
+		Kind      string `json:"kind"`
+		ExitCode  int    `json:"exitCode"`
+		Message   string `json:"message"`
+		Status    int    `json:"status,omitempty"`
+		ErrorCode int    `json:"errorCode,omitempty"`
+		UsrMsg    string `json:"usrMsg,omitempty"`
+		DevMsg    string `json:"devMsg,omitempty"`
+	} `json:"error"`
+}
+
+// reportError prints err to stderr and returns the process exit code.
+func reportError(err error) int {
+	code, kind := errorKind(err)
+
+	if !output.Structured() || output.Templated() {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return code
+	}
+
+	var je jsonError
+	je.Error.Kind = kind
+	je.Error.ExitCode = code
+	je.Error.Message = err.Error()
+	if apiErr, ok := client.AsAPIError(err); ok {
+		je.Error.Status = apiErr.StatusCode
+		je.Error.ErrorCode = apiErr.ErrorCode
+		je.Error.UsrMsg = apiErr.Message()
+		je.Error.DevMsg = apiErr.DevMsg
+	}
+	enc := json.NewEncoder(os.Stderr)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	enc.Encode(je)
+	return code
+}
@@ -3,8 +3,11 @@ package cmd
 import (
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/MagicLex/hopsworks-cli/pkg/client"
+	"github.com/MagicLex/hopsworks-cli/pkg/infer"
 	"github.com/MagicLex/hopsworks-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -13,12 +16,26 @@ var fgVersion int
 var fgPreviewN int
 var fgStatsFeatures string
 var fgStatsCompute bool
+var fgAsOf string
+var fgPreviewStorage string
 
 var fgCmd = &cobra.Command{
 	Use:   "fg",
 	Short: "Manage feature groups",
 }
 
+var fgListColumns = []output.Column[client.FeatureGroup]{
+	{Name: "NAME", Value: func(fg client.FeatureGroup) interface{} { return fg.Name }},
+	{Name: "VERSION", Value: func(fg client.FeatureGroup) interface{} { return fg.Version }},
+	{Name: "TYPE", Value: func(fg client.FeatureGroup) interface{} { return fg.FGTypeLabel() }},
+	{Name: "ONLINE", Value: func(fg client.FeatureGroup) interface{} { return fg.OnlineEnabled }},
+	{Name: "FEATURES", Value: func(fg client.FeatureGroup) interface{} { return len(fg.Features) }},
+	{Name: "DESCRIPTION", Flex: true, Value: func(fg client.FeatureGroup) interface{} { return fg.Description }},
+	{Name: "ID", Wide: true, Value: func(fg client.FeatureGroup) interface{} { return fg.ID }},
+	{Name: "CREATED", Wide: true, Value: func(fg client.FeatureGroup) interface{} { return apiTime(fg.Created) }},
+	{Name: "LOCATION", Wide: true, Flex: true, Value: func(fg client.FeatureGroup) interface{} { return fg.Location }},
+}
+
 var fgListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List feature groups",
@@ -28,34 +45,13 @@ var fgListCmd = &cobra.Command{
 			return err
 		}
 
-		fgs, err := c.ListFeatureGroups()
+		fgs, err := c.ListFeatureGroups(listPage())
 		if err != nil {
 			return err
 		}
+		fgs = trimPage(fgs)
 
-		if output.JSONMode {
-			output.PrintJSON(fgs)
-			return nil
-		}
-
-		headers := []string{"NAME", "VERSION", "TYPE", "ONLINE", "FEATURES", "DESCRIPTION"}
-		var rows [][]string
-		for _, fg := range fgs {
-			online := "no"
-			if fg.OnlineEnabled {
-				online = "yes"
-			}
-			rows = append(rows, []string{
-				fg.Name,
-				strconv.Itoa(fg.Version),
-				fg.FGTypeLabel(),
-				online,
-				strconv.Itoa(len(fg.Features)),
-				truncate(fg.Description, 40),
-			})
-		}
-		output.Table(headers, rows)
-		return nil
+		return printList(fgs, fgListColumns)
 	},
 }
 
@@ -99,13 +95,13 @@ var fgInfoCmd = &cobra.Command{
 
 		if len(fg.Features) > 0 {
 			headers := []string{"FEATURE", "TYPE", "PRIMARY"}
-			var rows [][]string
+			var rows []output.Row
 			for _, f := range fg.Features {
 				pk := ""
 				if f.Primary {
 					pk = "yes"
 				}
-				rows = append(rows, []string{f.Name, f.Type, pk})
+				rows = append(rows, output.Row{f.Name, f.Type, pk})
 			}
 			output.Table(headers, rows)
 		}
@@ -114,9 +110,9 @@ var fgInfoCmd = &cobra.Command{
 			output.Info("")
 			output.Info("Embeddings:")
 			headers := []string{"COLUMN", "DIMENSION", "METRIC"}
-			var rows [][]string
+			var rows []output.Row
 			for _, ef := range fg.EmbeddingIndex.Features {
-				rows = append(rows, []string{ef.Name, strconv.Itoa(ef.Dimension), ef.SimilarityFunctionType})
+				rows = append(rows, output.Row{ef.Name, ef.Dimension, ef.SimilarityFunctionType})
 			}
 			output.Table(headers, rows)
 		}
@@ -127,25 +123,58 @@ var fgInfoCmd = &cobra.Command{
 var fgPreviewCmd = &cobra.Command{
 	Use:   "preview <name>",
 	Short: "Preview feature group data",
-	Args:  cobra.ExactArgs(1),
+	Long: `Preview feature group data.
+
+Examples:
+  hops fg preview transactions --n 5
+  hops fg preview transactions --storage online
+
+  # Data as of a commit (see 'hops fg commits'), epoch millis or a time (Python SDK)
+  hops fg preview transactions --as-of 1718035200000
+  hops fg preview transactions --as-of "2024-06-10 14:00:00"`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		fgPreviewStorage = strings.ToLower(fgPreviewStorage)
+		if fgPreviewStorage != "offline" && fgPreviewStorage != "online" {
+			return fmt.Errorf("--storage must be offline or online")
+		}
+		if fgAsOf != "" && fgPreviewStorage == "online" {
+			return fmt.Errorf("--as-of reads the offline store; the online store keeps only the latest values")
+		}
+
 		c, err := mustClient()
 		if err != nil {
 			return err
 		}
 
-		fg, err := c.GetFeatureGroup(args[0], fgVersion)
+		fg, err := getFeatureGroupVersion(c, args[0], fgVersion)
 		if err != nil {
 			return err
 		}
+		if fgPreviewStorage == "online" && !fg.OnlineEnabled {
+			return fmt.Errorf("'%s' v%d is not online-enabled", fg.Name, fg.Version)
+		}
+
+		if fgAsOf != "" {
+			asOf, err := resolveAsOf(c, fg, fgAsOf)
+			if err != nil {
+				return err
+			}
+			output.Info("'%s' v%d as of %s", fg.Name, fg.Version, time.UnixMilli(asOf).Format("2006-01-02 15:04:05"))
+			script := buildFGAsOfScript(fg.Name, fg.Version, asOf, fgPreviewN, output.Structured())
+			if err := runDataFrameScript(script, ""); err != nil {
+				return fmt.Errorf("time-travel read: %w", err)
+			}
+			return nil
+		}
 
-		rows, err := c.PreviewFeatureGroup(fg.ID, fgPreviewN)
+		rows, err := c.PreviewFeatureGroup(fg.ID, fgPreviewN, fgPreviewStorage)
 		if err != nil {
 			return err
 		}
 
-		if output.JSONMode {
-			output.PrintJSON(rows)
+		if output.Structured() {
+			output.PrintKind("Row", rows)
 			return nil
 		}
 
@@ -166,11 +195,11 @@ var fgPreviewCmd = &cobra.Command{
 			}
 		}
 
-		var tableRows [][]string
+		var tableRows []output.Row
 		for _, row := range rows {
-			var r []string
+			var r output.Row
 			for _, h := range headers {
-				r = append(r, fmt.Sprintf("%v", row[h]))
+				r = append(r, row[h])
 			}
 			tableRows = append(tableRows, r)
 		}
@@ -194,19 +223,19 @@ var fgFeaturesCmd = &cobra.Command{
 			return err
 		}
 
-		if output.JSONMode {
+		if output.Structured() {
 			output.PrintJSON(fg.Features)
 			return nil
 		}
 
 		headers := []string{"NAME", "TYPE", "PRIMARY", "DESCRIPTION"}
-		var rows [][]string
+		var rows []output.Row
 		for _, f := range fg.Features {
 			pk := ""
 			if f.Primary {
 				pk = "yes"
 			}
-			rows = append(rows, []string{f.Name, f.Type, pk, f.Description})
+			rows = append(rows, output.Row{f.Name, f.Type, pk, f.Description})
 		}
 		output.Table(headers, rows)
 		return nil
@@ -221,6 +250,8 @@ var (
 	fgCreateFeatures   string
 	fgCreateFormat     string
 	fgCreateEmbeddings []string
+	fgCreateFromFile   string
+	fgCreateDryRun     bool
 )
 
 var fgCreateCmd = &cobra.Command{
@@ -244,7 +275,12 @@ Examples:
   hops fg create documents \
     --primary-key doc_id \
     --features "doc_id:bigint,title:string" \
-    --embedding "text_embedding:384:cosine"`,
+    --embedding "text_embedding:384:cosine"
+
+  # Schema inferred from a sample file (.csv, .tsv, .json, .ndjson, .parquet);
+  # --features overrides single types, the event time is detected if omitted
+  hops fg create transactions --from-file sample.csv --primary-key id \
+    --features "amount:decimal(10,2)" --dry-run`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if fgVersion == 0 {
@@ -254,11 +290,6 @@ Examples:
 			return fmt.Errorf("--primary-key is required")
 		}
 
-		c, err := mustClient()
-		if err != nil {
-			return err
-		}
-
 		pks := splitComma(fgCreatePK)
 		pkSet := make(map[string]bool)
 		for _, pk := range pks {
@@ -266,21 +297,20 @@ Examples:
 		}
 
 		var features []client.Feature
-		if fgCreateFeatures != "" {
-			// Parse "name:type,name:type,..." format
-			for _, spec := range splitComma(fgCreateFeatures) {
-				parts := splitStr(trimSpace(spec), ":")
-				name := trimSpace(parts[0])
-				typ := "string"
-				if len(parts) > 1 {
-					typ = trimSpace(parts[1])
-				}
-				features = append(features, client.Feature{
-					Name:    name,
-					Type:    typ,
-					Primary: pkSet[name],
-				})
+		if fgCreateFromFile != "" {
+			var err error
+			features, err = featuresFromFile(fgCreateFromFile, fgCreateFeatures, pks)
+			if err != nil {
+				return err
 			}
+			fgCreateEvtTime = strings.ToLower(fgCreateEvtTime)
+			if fgCreateEvtTime == "" {
+				fgCreateEvtTime = detectEventTime(features)
+			} else if findFeature(features, fgCreateEvtTime) < 0 {
+				return fmt.Errorf("--event-time: column '%s' is not in %s", fgCreateEvtTime, fgCreateFromFile)
+			}
+		} else if fgCreateFeatures != "" {
+			features = parseFeatureSpecs(fgCreateFeatures, pkSet)
 		} else {
 			// Minimal: just primary keys
 			for _, pk := range pks {
@@ -326,6 +356,22 @@ Examples:
 			EmbeddingIndex:   embeddingIndex,
 		}
 
+		if fgCreateDryRun {
+			req.ApplyDefaults(cfg.FeatureStoreID)
+			if output.JSONMode {
+				output.PrintJSON(req)
+				return nil
+			}
+			printCreateRequest(req)
+			output.Info("Dry run: nothing was created (--json prints the request body)")
+			return nil
+		}
+
+		c, err := mustClient()
+		if err != nil {
+			return err
+		}
+
 		fg, err := c.CreateFeatureGroup(req)
 		if err != nil {
 			return err
@@ -352,6 +398,9 @@ Examples:
   # Filter to specific features
   hops fg stats transactions --features amount,age
 
+  # Stats as of a commit (see 'hops fg commits'), epoch millis or a time
+  hops fg stats transactions --as-of "2024-06-10 14:00:00"
+
   # Trigger stats computation (Spark job)
   hops fg stats transactions --compute`,
 	Args: cobra.ExactArgs(1),
@@ -361,7 +410,7 @@ Examples:
 			return err
 		}
 
-		fg, err := c.GetFeatureGroup(args[0], fgVersion)
+		fg, err := getFeatureGroupVersion(c, args[0], fgVersion)
 		if err != nil {
 			return err
 		}
@@ -384,21 +433,32 @@ Examples:
 			featureNames = splitComma(fgStatsFeatures)
 		}
 
-		stats, err := c.GetFeatureGroupStatistics(fg.ID, featureNames)
+		var asOf int64
+		if fgAsOf != "" {
+			if asOf, err = resolveAsOf(c, fg, fgAsOf); err != nil {
+				return err
+			}
+		}
+
+		stats, err := c.GetFeatureGroupStatistics(fg.ID, featureNames, asOf)
 		if err != nil {
 			return err
 		}
 
 		if stats == nil {
 			if output.JSONMode {
-				output.PrintJSON(struct{}{})
+				output.PrintJSON(&client.Statistics{})
+				return nil
+			}
+			if asOf > 0 {
+				output.Info("No statistics for '%s' v%d as of %s", fg.Name, fg.Version, time.UnixMilli(asOf).Format("2006-01-02 15:04:05"))
 				return nil
 			}
 			output.Info("No statistics computed for '%s' v%d. Use --compute to trigger.", fg.Name, fg.Version)
 			return nil
 		}
 
-		if output.JSONMode {
+		if output.Structured() {
 			output.PrintJSON(stats)
 			return nil
 		}
@@ -416,9 +476,9 @@ Examples:
 		}
 
 		headers := []string{"FEATURE", "TYPE", "COUNT", "MEAN", "MIN", "MAX", "STDDEV", "NULLS", "COMPLETENESS"}
-		var rows [][]string
+		var rows []output.Row
 		for _, fs := range stats.FeatureDescriptiveStatistics {
-			rows = append(rows, []string{
+			rows = append(rows, output.Row{
 				fs.FeatureName,
 				fs.FeatureType,
 				fmtInt64(fs.Count),
@@ -438,10 +498,21 @@ Examples:
 var fgDeleteCmd = &cobra.Command{
 	Use:   "delete <name>",
 	Short: "Delete a feature group",
-	Args:  cobra.ExactArgs(1),
+	Long: `Delete a feature group version, or all of them with --all-versions.
+
+Before deleting, lists what depends on it: feature views built on it, feature
+groups derived from it, the training datasets and models of those feature
+views, and the deployments serving the models. If anything does, asks for
+confirmation, or needs --force when not run from a terminal.
+
+Examples:
+  hops fg delete transactions --version 1 --dry-run
+  hops fg delete transactions --version 1
+  hops fg delete transactions --all-versions --force`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if fgVersion == 0 {
-			return fmt.Errorf("--version is required for delete")
+		if (fgVersion > 0) == deleteAllVersions {
+			return fmt.Errorf("one of --version or --all-versions is required for delete")
 		}
 
 		c, err := mustClient()
@@ -449,17 +520,36 @@ var fgDeleteCmd = &cobra.Command{
 			return err
 		}
 
-		fg, err := c.GetFeatureGroup(args[0], fgVersion)
-		if err != nil {
-			return err
+		var fgs []client.FeatureGroup
+		if deleteAllVersions {
+			if fgs, err = c.ListFeatureGroupVersions(args[0]); err != nil {
+				return err
+			}
+			if len(fgs) == 0 {
+				return fmt.Errorf("feature group '%s' not found", args[0])
+			}
+		} else {
+			fg, err := getFeatureGroupVersion(c, args[0], fgVersion)
+			if err != nil {
+				return err
+			}
+			fgs = []client.FeatureGroup{*fg}
 		}
 
-		if err := c.DeleteFeatureGroup(fg.ID); err != nil {
+		scan := newDependencyScan(c)
+		for _, fg := range fgs {
+			scan.target(artifactRef{Kind: "featureGroup", Name: fg.Name, Version: fg.Version})
+		}
+		for i := range fgs {
+			scan.featureGroup(&fgs[i])
+		}
+		if ok, err := confirmDelete(scan); !ok {
 			return err
 		}
 
-		output.Success("Deleted feature group '%s' v%d", args[0], fgVersion)
-		return nil
+		return deleteTargets(scan, func(i int) error {
+			return c.DeleteFeatureGroup(fgs[i].ID)
+		})
 	},
 }
 
@@ -483,8 +573,8 @@ var fgKeywordsCmd = &cobra.Command{
 			return err
 		}
 
-		if output.JSONMode {
-			output.PrintJSON(keywords)
+		if output.Structured() {
+			output.PrintKind("Keyword", keywords)
 			return nil
 		}
 
@@ -494,9 +584,9 @@ var fgKeywordsCmd = &cobra.Command{
 		}
 
 		headers := []string{"KEYWORD"}
-		var rows [][]string
+		var rows []output.Row
 		for _, kw := range keywords {
-			rows = append(rows, []string{kw})
+			rows = append(rows, output.Row{kw})
 		}
 		output.Table(headers, rows)
 		return nil
@@ -541,7 +631,7 @@ var fgAddKeywordCmd = &cobra.Command{
 		}
 
 		if output.JSONMode {
-			output.PrintJSON(updated)
+			output.PrintKind("Keyword", updated)
 			return nil
 		}
 
@@ -575,7 +665,7 @@ var fgRemoveKeywordCmd = &cobra.Command{
 			if err != nil {
 				return err
 			}
-			output.PrintJSON(keywords)
+			output.PrintKind("Keyword", keywords)
 			return nil
 		}
 
@@ -636,6 +726,8 @@ func init() {
 	fgInfoCmd.Flags().IntVar(&fgVersion, "version", 0, "Feature group version (latest if omitted)")
 	fgPreviewCmd.Flags().IntVar(&fgVersion, "version", 0, "Feature group version")
 	fgPreviewCmd.Flags().IntVar(&fgPreviewN, "n", 10, "Number of rows to preview")
+	fgPreviewCmd.Flags().StringVar(&fgAsOf, "as-of", "", "Read the data as of a commit ID, epoch millis or time (Python SDK)")
+	fgPreviewCmd.Flags().StringVar(&fgPreviewStorage, "storage", "offline", "Store to read: offline or online")
 	fgFeaturesCmd.Flags().IntVar(&fgVersion, "version", 0, "Feature group version")
 	fgCreateCmd.Flags().IntVar(&fgVersion, "version", 1, "Feature group version")
 	fgCreateCmd.Flags().StringVar(&fgCreatePK, "primary-key", "", "Primary key columns (comma-separated)")
@@ -644,15 +736,20 @@ func init() {
 	fgCreateCmd.Flags().StringVar(&fgCreateEvtTime, "event-time", "", "Event time column")
 	fgCreateCmd.Flags().StringVar(&fgCreateDesc, "description", "", "Description")
 	fgCreateCmd.Flags().StringVar(&fgCreateFormat, "format", "DELTA", "Time travel format: DELTA or NONE")
+	fgCreateCmd.Flags().StringVar(&fgCreateFromFile, "from-file", "", "Infer the schema from a sample file (.csv, .tsv, .json, .ndjson, .parquet)")
+	fgCreateCmd.Flags().BoolVar(&fgCreateDryRun, "dry-run", false, "Print the create request without sending it")
 	fgCreateCmd.Flags().StringArrayVar(&fgCreateEmbeddings, "embedding", nil, `Embedding column: "name:dimension[:metric]" (l2, cosine, dot_product)`)
 	fgDeleteCmd.Flags().IntVar(&fgVersion, "version", 0, "Feature group version to delete")
+	addDeleteFlags(fgDeleteCmd)
 	fgStatsCmd.Flags().IntVar(&fgVersion, "version", 0, "Feature group version")
 	fgStatsCmd.Flags().StringVar(&fgStatsFeatures, "features", "", "Filter to specific features (comma-separated)")
 	fgStatsCmd.Flags().BoolVar(&fgStatsCompute, "compute", false, "Trigger statistics computation (Spark job)")
+	fgStatsCmd.Flags().StringVar(&fgAsOf, "as-of", "", "Latest statistics as of a commit ID, epoch millis or time")
 	fgKeywordsCmd.Flags().IntVar(&fgVersion, "version", 0, "Feature group version")
 	fgAddKeywordCmd.Flags().IntVar(&fgVersion, "version", 0, "Feature group version")
 	fgRemoveKeywordCmd.Flags().IntVar(&fgVersion, "version", 0, "Feature group version")
 
+	addListFlags(fgListCmd)
 	fgCmd.AddCommand(fgListCmd)
 	fgCmd.AddCommand(fgInfoCmd)
 	fgCmd.AddCommand(fgPreviewCmd)
@@ -665,12 +762,29 @@ func init() {
 	fgCmd.AddCommand(fgRemoveKeywordCmd)
 }
 
+// Helper: create client bound to the command context (cancelled on Ctrl-C)
+func newClient() (*client.Client, error) {
+	c, err := client.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return c.WithContext(cmdCtx), nil
+}
+
 // Helper: create client with project validation
 func mustClient() (*client.Client, error) {
-	c, err := client.New(cfg)
+	c, err := newClient()
 	if err != nil {
 		return nil, err
 	}
+	if cfg.ProjectID == 0 && cfg.Project != "" {
+		// Project given by name only (--project, PROJECT_NAME, .hops.yaml)
+		project, err := c.GetProjectByName(cfg.Project)
+		if err != nil {
+			return nil, fmt.Errorf("resolve project: %w", err)
+		}
+		cfg.ProjectID = project.ProjectID
+	}
 	if cfg.ProjectID == 0 {
 		return nil, fmt.Errorf("no project selected. Run 'hops project use <name>' first")
 	}
@@ -679,16 +793,167 @@ func mustClient() (*client.Client, error) {
 		if err := resolveFeatureStoreID(c); err != nil {
 			return nil, fmt.Errorf("could not resolve feature store: %w", err)
 		}
-		cfg.Save()
+		if !cfg.Internal {
+			cfg.Save()
+		}
 	}
 	return c, nil
 }
 
-func truncate(s string, n int) string {
-	if len(s) <= n {
-		return s
+// getFeatureGroupVersion is GetFeatureGroup that fails, instead of falling
+// back to the latest version, when the requested version doesn't exist.
+func getFeatureGroupVersion(c *client.Client, name string, version int) (*client.FeatureGroup, error) {
+	fg, err := c.GetFeatureGroup(name, version)
+	if err != nil {
+		return nil, err
+	}
+	if version > 0 && fg.Version != version {
+		return nil, fmt.Errorf("feature group '%s' has no version %d (latest is v%d)", name, version, fg.Version)
+	}
+	return fg, nil
+}
+
+// parseFeatureSpecs parses "name:type,name:type,..." (type defaults to string),
+// marking the names in pkSet as primary keys. Commas and colons inside a type
+// (decimal(10,2), struct<a:int,b:string>) don't split it.
+func parseFeatureSpecs(specs string, pkSet map[string]bool) []client.Feature {
+	var features []client.Feature
+	for _, spec := range splitTopLevel(specs) {
+		name, typ, ok := strings.Cut(spec, ":")
+		name = trimSpace(name)
+		if typ = trimSpace(typ); !ok || typ == "" {
+			typ = "string"
+		}
+		features = append(features, client.Feature{
+			Name:    name,
+			Type:    typ,
+			Primary: pkSet[name],
+		})
+	}
+	return features
+}
+
+// featuresFromFile infers features from a sample data file. overrides
+// ("name:type,...") replace the inferred type of single columns. Names are
+// lowercased like the columns, so they match however they're cased.
+func featuresFromFile(path, overrides string, pks []string) ([]client.Feature, error) {
+	cols, err := infer.File(path)
+	if err != nil {
+		return nil, err
+	}
+	features := make([]client.Feature, len(cols))
+	for i, col := range cols {
+		// Hopsworks lowercases feature names
+		features[i] = client.Feature{Name: strings.ToLower(col.Name), Type: col.Type}
+	}
+	if overrides != "" {
+		for _, o := range parseFeatureSpecs(overrides, nil) {
+			i := findFeature(features, strings.ToLower(o.Name))
+			if i < 0 {
+				return nil, fmt.Errorf("--features: column '%s' is not in %s", o.Name, path)
+			}
+			features[i].Type = o.Type
+		}
+	}
+	for _, pk := range pks {
+		i := findFeature(features, strings.ToLower(pk))
+		if i < 0 {
+			return nil, fmt.Errorf("primary key '%s' is not a column of %s", pk, path)
+		}
+		features[i].Primary = true
+	}
+	return features, nil
+}
+
+// eventTimeNames are column names that usually hold the event time, best first.
+var eventTimeNames = []string{"event_time", "event_ts", "event_timestamp", "timestamp", "ts", "datetime", "event_date", "created_at", "updated_at", "date"}
+
+// detectEventTime picks the event-time column among timestamp and date
+// features: a well-known name, else a *_time/*_ts/*_at name, else the only
+// candidate. It returns "" (and says why) when there is no clear choice.
+func detectEventTime(features []client.Feature) string {
+	var candidates []string
+	for _, f := range features {
+		if !f.Primary && (f.Type == "timestamp" || f.Type == "date") {
+			candidates = append(candidates, f.Name)
+		}
+	}
+	pick := func() string {
+		for _, name := range eventTimeNames {
+			for _, c := range candidates {
+				if c == name {
+					return c
+				}
+			}
+		}
+		for _, c := range candidates {
+			if strings.HasSuffix(c, "_time") || strings.HasSuffix(c, "_ts") || strings.HasSuffix(c, "_at") {
+				return c
+			}
+		}
+		if len(candidates) == 1 {
+			return candidates[0]
+		}
+		return ""
+	}
+
+	name := pick()
+	switch {
+	case name != "":
+		output.Info("Using '%s' as event time (override with --event-time)", name)
+	case len(candidates) > 1:
+		output.Info("Several time columns (%s); pass --event-time to pick one", strings.Join(candidates, ", "))
+	}
+	return name
+}
+
+// printCreateRequest summarizes a feature group create request.
+func printCreateRequest(req *client.CreateFeatureGroupRequest) {
+	output.Info("Feature group '%s' v%d (%s)", req.Name, req.Version, req.Type)
+	if req.EventTime != "" {
+		output.Info("Event time: %s", req.EventTime)
+	}
+	if req.OnlineEnabled {
+		output.Info("Online: yes")
+	}
+	rows := make([]output.Row, len(req.Features))
+	for i, f := range req.Features {
+		pk := ""
+		if f.Primary {
+			pk = "yes"
+		}
+		rows[i] = output.Row{f.Name, f.Type, pk}
+	}
+	output.Table([]string{"NAME", "TYPE", "PRIMARY"}, rows)
+}
+
+// splitTopLevel is splitComma ignoring commas inside <> and ().
+func splitTopLevel(s string) []string {
+	var result []string
+	depth, start := 0, 0
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) {
+			switch s[i] {
+			case '<', '(':
+				depth++
+				continue
+			case '>', ')':
+				depth--
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		if part := trimSpace(s[start:i]); part != "" {
+			result = append(result, part)
+		}
+		start = i + 1
 	}
-	return s[:n-3] + "..."
+	return result
 }
 
 func splitComma(s string) []string {
@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
+	"github.com/MagicLex/hopsworks-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var fgConsistencySample int
+
+// consistencyReport is the result of 'hops fg consistency'.
+type consistencyReport struct {
+	FeatureGroup string                   `json:"featureGroup"`
+	Version      int                      `json:"version"`
+	Sampled      int                      `json:"sampled"`
+	Consistent   bool                     `json:"consistent"`
+	Missing      []map[string]interface{} `json:"missing"`    // primary keys absent online
+	Mismatched   []valueMismatch          `json:"mismatched"` // per row and feature
+	ByFeature    map[string]int           `json:"mismatchesByFeature"`
+}
+
+// valueMismatch is a feature whose online value differs from the offline one.
+type valueMismatch struct {
+	Key     map[string]interface{} `json:"key"`
+	Feature string                 `json:"feature"`
+	Offline interface{}            `json:"offline"`
+	Online  interface{}            `json:"online"`
+}
+
+var fgConsistencyCmd = &cobra.Command{
+	Use:   "consistency <name>",
+	Short: "Check that the online store matches the offline store",
+	Long: `Sample primary keys from the offline store, look the same keys up in the
+online store, and report rows missing online and features whose values differ.
+Catches online serving that drifted after a failed materialization job.
+
+Exits 1 when anything is missing or different.
+
+Examples:
+  hops fg consistency transactions
+  hops fg consistency transactions --sample 500 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if fgConsistencySample <= 0 {
+			return fmt.Errorf("--sample must be positive")
+		}
+
+		c, err := mustClient()
+		if err != nil {
+			return err
+		}
+
+		fg, err := getFeatureGroupVersion(c, args[0], fgVersion)
+		if err != nil {
+			return err
+		}
+		if !fg.OnlineEnabled {
+			return fmt.Errorf("'%s' v%d is not online-enabled", fg.Name, fg.Version)
+		}
+		pks := primaryKeys(fg.Features)
+		if len(pks) == 0 {
+			return fmt.Errorf("'%s' v%d has no primary key", fg.Name, fg.Version)
+		}
+
+		offline, err := c.PreviewFeatureGroup(fg.ID, fgConsistencySample, "offline")
+		if err != nil {
+			return fmt.Errorf("offline preview: %w", err)
+		}
+		report := &consistencyReport{
+			FeatureGroup: fg.Name,
+			Version:      fg.Version,
+			Sampled:      len(offline),
+			Missing:      []map[string]interface{}{},
+			Mismatched:   []valueMismatch{},
+			ByFeature:    map[string]int{},
+		}
+
+		if len(offline) > 0 {
+			output.Info("Looking up %d keys in the online store...", len(offline))
+			var online []map[string]interface{}
+			if err := captureJSON(buildOnlineLookupScript(fg, offline), &online); err != nil {
+				return fmt.Errorf("online lookup: %w", err)
+			}
+			compareStores(report, fg, offline, online)
+		}
+		report.Consistent = len(report.Missing) == 0 && len(report.Mismatched) == 0
+
+		if output.JSONMode {
+			output.PrintJSON(report)
+		} else {
+			printConsistencyReport(report, pks)
+		}
+		if !report.Consistent {
+			return fmt.Errorf("online store of '%s' v%d differs from offline: %d missing, %d mismatched value(s)",
+				fg.Name, fg.Version, len(report.Missing), len(report.Mismatched))
+		}
+		return nil
+	},
+}
+
+// buildOnlineLookupScript reads the rows with the sampled primary keys from
+// the online store. Composite keys are filtered per column; compareStores
+// matches whole keys.
+func buildOnlineLookupScript(fg *client.FeatureGroup, offline []map[string]interface{}) string {
+	var sb strings.Builder
+	sb.WriteString(`import hopsworks, warnings, logging, json, sys
+import pandas as pd
+warnings.filterwarnings("ignore")
+logging.getLogger("hsfs").setLevel(logging.WARNING)
+logging.getLogger("hopsworks").setLevel(logging.WARNING)
+
+project = hopsworks.login()
+fs = project.get_feature_store()
+`)
+	sb.WriteString(fmt.Sprintf("fg = fs.get_feature_group(%q, version=%d)\n", fg.Name, fg.Version))
+	sb.WriteString("q = fg.select_all()\n")
+	for _, f := range fg.Features {
+		if !f.Primary {
+			continue
+		}
+		seen := map[string]bool{}
+		var values []string
+		for _, row := range offline {
+			lit := keyLiteral(row[f.Name], f.Type)
+			if !seen[lit] {
+				seen[lit] = true
+				values = append(values, lit)
+			}
+		}
+		sb.WriteString(fmt.Sprintf("q = q.filter(fg.get_feature(%q).isin([%s]))\n", f.Name, strings.Join(values, ", ")))
+	}
+	sb.WriteString("df = q.read(online=True)\n")
+	sb.WriteString(printDataFrame(true))
+	return sb.String()
+}
+
+// keyLiteral writes a primary key value as a Python literal of the feature's type.
+func keyLiteral(v interface{}, typ string) string {
+	if v == nil {
+		return "None"
+	}
+	s := fmt.Sprint(v)
+	switch strings.ToLower(typ) {
+	case "tinyint", "smallint", "int", "bigint", "float", "double":
+		if _, err := strconv.ParseFloat(s, 64); err == nil {
+			return pythonLiteral(s)
+		}
+	}
+	return fmt.Sprintf("%q", s)
+}
+
+// compareStores fills report with the offline rows that are missing online
+// or whose non-key features differ.
+func compareStores(report *consistencyReport, fg *client.FeatureGroup, offline, online []map[string]interface{}) {
+	pks := primaryKeys(fg.Features)
+	rowKey := func(row map[string]interface{}) string {
+		parts := make([]string, len(pks))
+		for i, pk := range pks {
+			parts[i] = normalizeValue(row[pk])
+		}
+		return strings.Join(parts, "\x00")
+	}
+
+	byKey := make(map[string]map[string]interface{}, len(online))
+	for _, row := range online {
+		byKey[rowKey(row)] = row
+	}
+
+	for _, off := range offline {
+		key := make(map[string]interface{}, len(pks))
+		for _, pk := range pks {
+			key[pk] = off[pk]
+		}
+		on, ok := byKey[rowKey(off)]
+		if !ok {
+			report.Missing = append(report.Missing, key)
+			continue
+		}
+		for _, f := range fg.Features {
+			if f.Primary {
+				continue
+			}
+			if !sameValue(off[f.Name], on[f.Name]) {
+				report.Mismatched = append(report.Mismatched, valueMismatch{
+					Key: key, Feature: f.Name, Offline: off[f.Name], Online: on[f.Name],
+				})
+				report.ByFeature[f.Name]++
+			}
+		}
+	}
+}
+
+// storeTimeLayouts cover how the offline preview (Hive) and pandas write times.
+var storeTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// normalizeValue renders a value from either store in one canonical form:
+// the preview returns everything as text, pandas returns typed JSON.
+func normalizeValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	var s string
+	switch t := v.(type) {
+	case string:
+		s = strings.TrimSpace(t)
+	case float64:
+		return strconv.FormatFloat(t, 'g', -1, 64)
+	default:
+		b, _ := json.Marshal(t)
+		s = string(b)
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	for _, layout := range storeTimeLayouts {
+		if ts, err := time.Parse(layout, s); err == nil {
+			return ts.UTC().Format(time.RFC3339Nano)
+		}
+	}
+	return s
+}
+
+// sameValue compares values across stores; floats get a relative tolerance
+// since the online store may hold them as float rather than double.
+func sameValue(a, b interface{}) bool {
+	na, nb := normalizeValue(a), normalizeValue(b)
+	if na == nb {
+		return true
+	}
+	fa, errA := strconv.ParseFloat(na, 64)
+	fb, errB := strconv.ParseFloat(nb, 64)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return math.Abs(fa-fb) <= 1e-6*math.Max(math.Abs(fa), math.Abs(fb))
+}
+
+// maxReportRows caps the missing and mismatch tables; --json has them all.
+const maxReportRows = 20
+
+func printConsistencyReport(r *consistencyReport, pks []string) {
+	output.Info("'%s' v%d: sampled %d offline rows, %d missing online, %d mismatched value(s)",
+		r.FeatureGroup, r.Version, r.Sampled, len(r.Missing), len(r.Mismatched))
+	if r.Consistent {
+		output.Success("Online store matches the offline sample")
+		return
+	}
+
+	formatKey := func(key map[string]interface{}) string {
+		parts := make([]string, len(pks))
+		for i, pk := range pks {
+			parts[i] = fmt.Sprintf("%s=%v", pk, key[pk])
+		}
+		return strings.Join(parts, ",")
+	}
+
+	if len(r.Missing) > 0 {
+		fmt.Println()
+		rows := make([]output.Row, 0, maxReportRows)
+		for _, key := range r.Missing[:min(len(r.Missing), maxReportRows)] {
+			rows = append(rows, output.Row{formatKey(key)})
+		}
+		output.Table([]string{"MISSING ONLINE"}, rows)
+		if len(r.Missing) > maxReportRows {
+			output.Info("... and %d more (--json lists all)", len(r.Missing)-maxReportRows)
+		}
+	}
+
+	if len(r.ByFeature) > 0 {
+		fmt.Println()
+		names := make([]string, 0, len(r.ByFeature))
+		for name := range r.ByFeature {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool { return r.ByFeature[names[i]] > r.ByFeature[names[j]] })
+		rows := make([]output.Row, len(names))
+		for i, name := range names {
+			rows[i] = output.Row{name, r.ByFeature[name]}
+		}
+		output.Table([]string{"FEATURE", "MISMATCHES"}, rows)
+
+		fmt.Println()
+		rows = make([]output.Row, 0, maxReportRows)
+		for _, m := range r.Mismatched[:min(len(r.Mismatched), maxReportRows)] {
+			rows = append(rows, output.Row{formatKey(m.Key), m.Feature, m.Offline, m.Online})
+		}
+		output.Table([]string{"KEY", "FEATURE", "OFFLINE", "ONLINE"}, rows)
+		if len(r.Mismatched) > maxReportRows {
+			output.Info("... and %d more (--json lists all)", len(r.Mismatched)-maxReportRows)
+		}
+	}
+}
+
+func init() {
+	fgConsistencyCmd.Flags().IntVar(&fgVersion, "version", 0, "Feature group version (latest if omitted)")
+	fgConsistencyCmd.Flags().IntVar(&fgConsistencySample, "sample", 100, "Number of offline rows to check")
+
+	fgCmd.AddCommand(fgConsistencyCmd)
+}
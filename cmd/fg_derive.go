@@ -2,8 +2,7 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
+	"io"
 	"strings"
 
 	"github.com/MagicLex/hopsworks-cli/pkg/output"
@@ -79,6 +78,14 @@ func parseJoinSpec(spec string) (joinSpec, error) {
 	}, nil
 }
 
+// deriveResult is the JSON form of 'hops fg derive'.
+type deriveResult struct {
+	Status       string `json:"status"`
+	FeatureGroup string `json:"feature_group"`
+	Base         string `json:"base"`
+	Joins        int    `json:"joins"`
+}
+
 var fgDeriveCmd = &cobra.Command{
 	Use:   "derive <name>",
 	Short: "Create a feature group by joining existing ones",
@@ -157,25 +164,22 @@ Examples:
 
 		pyScript := buildDeriveScript(targetName, baseName, baseVersion, joins)
 
-		pyCmd := exec.Command("python3", "-c", pyScript)
-		pyCmd.Stdout = os.Stdout
-		pyCmd.Stderr = os.Stderr
-
-		pyCmd.Env = append(os.Environ(),
-			"PEMS_DIR="+os.ExpandEnv("${HOME}/.hopsfs_pems"),
-			"LIBHDFS_DEFAULT_USER="+os.Getenv("HADOOP_USER_NAME"),
-		)
-
-		if err := pyCmd.Run(); err != nil {
+		err = withTokenRetry(func(stderr io.Writer) error {
+			pyCmd := pythonCmd(pyScript)
+			pyCmd.Stdout = scriptStdout()
+			pyCmd.Stderr = stderr
+			return pyCmd.Run()
+		})
+		if err != nil {
 			return fmt.Errorf("derive feature group: %w", err)
 		}
 
 		if output.JSONMode {
-			output.PrintJSON(map[string]interface{}{
-				"status":        "success",
-				"feature_group": targetName,
-				"base":          baseName,
-				"joins":         len(joins),
+			output.PrintJSON(&deriveResult{
+				Status:       "success",
+				FeatureGroup: targetName,
+				Base:         baseName,
+				Joins:        len(joins),
 			})
 		}
 		return nil
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
+	"github.com/MagicLex/hopsworks-cli/pkg/infer"
+	"github.com/MagicLex/hopsworks-cli/pkg/output"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	fgDiffFrom int
+	fgDiffTo   int
+	fgDiffFile string
+)
+
+var fgDiffCmd = &cobra.Command{
+	Use:   "diff <name>",
+	Short: "Compare a feature group's schema across versions or with a local file",
+	Long: `Compare two versions of a feature group, or a registered version with a local
+file: added, removed and retyped features, primary key, event time, online and
+statistics changes, and feature descriptions.
+
+--file takes a data file (.csv, .tsv, .json, .ndjson, .parquet), whose types are
+inferred locally, or a schema file (.yaml):
+
+  primary_key: [id]
+  event_time: ts
+  features:
+    - name: id
+      type: bigint
+    - name: amount
+      type: double
+      description: Amount in EUR
+
+Exits 1 when a change can't be applied in place (removed or retyped feature,
+new primary key or event time), so it can gate CI.
+
+Examples:
+  hops fg diff transactions --from 1 --to 2
+  hops fg diff transactions --from 1              # against the latest version
+  hops fg diff transactions --file export.parquet
+  hops fg diff transactions --version 2 --file schema.yaml --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if (fgDiffFrom > 0) == (fgDiffFile != "") {
+			return fmt.Errorf("exactly one of --from or --file is required")
+		}
+		if fgDiffFile != "" && fgDiffTo > 0 {
+			return fmt.Errorf("--to compares versions; use --version to pick the one compared with --file")
+		}
+
+		c, err := mustClient()
+		if err != nil {
+			return err
+		}
+
+		var from, to *client.FeatureGroup
+		var toLabel string
+		if fgDiffFile != "" {
+			if from, err = getFeatureGroupVersion(c, args[0], fgVersion); err != nil {
+				return err
+			}
+			if to, err = featureGroupFromFile(from, fgDiffFile); err != nil {
+				return err
+			}
+			toLabel = fgDiffFile
+		} else {
+			if from, err = getFeatureGroupVersion(c, args[0], fgDiffFrom); err != nil {
+				return err
+			}
+			if to, err = getFeatureGroupVersion(c, args[0], fgDiffTo); err != nil {
+				return err
+			}
+			toLabel = fmt.Sprintf("v%d", to.Version)
+		}
+
+		changes := diffFeatureGroups(from, to)
+		if output.JSONMode {
+			output.PrintJSON(changes)
+		} else {
+			output.Info("'%s' v%d → %s", from.Name, from.Version, toLabel)
+			if len(changes) == 0 {
+				output.Info("No differences")
+			}
+			printSchemaChanges(changes)
+		}
+
+		var incompatible int
+		for _, ch := range changes {
+			if ch.Incompatible {
+				incompatible++
+			}
+		}
+		if incompatible > 0 {
+			return fmt.Errorf("%d incompatible change(s) between '%s' v%d and %s", incompatible, from.Name, from.Version, toLabel)
+		}
+		return nil
+	},
+}
+
+// schemaFile is the layout of a schema .yaml given to fg diff --file. Unset
+// fields keep the registered value.
+type schemaFile struct {
+	Description string   `yaml:"description"`
+	PrimaryKey  []string `yaml:"primary_key"`
+	EventTime   string   `yaml:"event_time"`
+	Online      *bool    `yaml:"online"`
+	Features    []struct {
+		Name        string `yaml:"name"`
+		Type        string `yaml:"type"`
+		Description string `yaml:"description"`
+		Primary     bool   `yaml:"primary"`
+	} `yaml:"features"`
+}
+
+// featureGroupFromFile returns registered with its schema replaced by the one
+// in path: a schema .yaml, or the columns inferred from a data file.
+func featureGroupFromFile(registered *client.FeatureGroup, path string) (*client.FeatureGroup, error) {
+	fg := *registered
+	fg.Features = nil
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".yaml" && ext != ".yml" {
+		cols, err := infer.File(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, col := range cols {
+			// Hopsworks lowercases feature names on insert
+			f := client.Feature{Name: strings.ToLower(col.Name), Type: col.Type}
+			if i := findFeature(registered.Features, f.Name); i >= 0 {
+				reg := registered.Features[i]
+				f.Primary, f.Description = reg.Primary, reg.Description
+				if fitsType(f.Type, reg.Type) {
+					f.Type = reg.Type
+				}
+			}
+			fg.Features = append(fg.Features, f)
+		}
+		return &fg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var sf schemaFile
+	if err := yaml.Unmarshal(data, &sf); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(sf.Features) == 0 {
+		return nil, fmt.Errorf("%s: no features", path)
+	}
+
+	pkSet := make(map[string]bool)
+	for _, pk := range sf.PrimaryKey {
+		pkSet[pk] = true
+	}
+	for _, sfF := range sf.Features {
+		if sfF.Primary {
+			pkSet[sfF.Name] = true
+		}
+	}
+	for _, sfF := range sf.Features {
+		f := client.Feature{Name: sfF.Name, Type: sfF.Type, Description: sfF.Description, Primary: pkSet[sfF.Name]}
+		if f.Type == "" {
+			return nil, fmt.Errorf("%s: feature '%s' has no type", path, f.Name)
+		}
+		if i := findFeature(registered.Features, f.Name); i >= 0 {
+			if len(pkSet) == 0 {
+				f.Primary = registered.Features[i].Primary
+			}
+			if f.Description == "" {
+				f.Description = registered.Features[i].Description
+			}
+		}
+		fg.Features = append(fg.Features, f)
+	}
+	if sf.Description != "" {
+		fg.Description = sf.Description
+	}
+	if sf.EventTime != "" {
+		fg.EventTime = sf.EventTime
+	}
+	if sf.Online != nil {
+		fg.OnlineEnabled = *sf.Online
+	}
+	return &fg, nil
+}
+
+// fitsType reports whether values inferred as type from load into a feature
+// of type to as they are. Inference can't tell int from bigint, or double from
+// decimal, so any integer fits any number type.
+func fitsType(from, to string) bool {
+	from, to = strings.ToLower(from), strings.ToLower(to)
+	if from == to {
+		return true
+	}
+	integer := func(t string) bool {
+		return t == "tinyint" || t == "smallint" || t == "int" || t == "bigint"
+	}
+	floating := func(t string) bool {
+		return t == "float" || t == "double" || strings.HasPrefix(t, "decimal")
+	}
+	switch {
+	case integer(from):
+		return integer(to) || floating(to)
+	case floating(from):
+		return floating(to)
+	case from == "date":
+		return to == "timestamp"
+	}
+	fromElem, fromArray := infer.ElemType(from)
+	toElem, toArray := infer.ElemType(to)
+	return fromArray && toArray && fitsType(fromElem, toElem)
+}
+
+func init() {
+	fgDiffCmd.Flags().IntVar(&fgDiffFrom, "from", 0, "Version to compare from")
+	fgDiffCmd.Flags().IntVar(&fgDiffTo, "to", 0, "Version to compare to (latest if omitted)")
+	fgDiffCmd.Flags().StringVar(&fgDiffFile, "file", "", "Compare with a data file (.csv, .json, .ndjson, .parquet) or schema .yaml")
+	fgDiffCmd.Flags().IntVar(&fgVersion, "version", 0, "Registered version to compare with --file (latest if omitted)")
+
+	fgCmd.AddCommand(fgDiffCmd)
+}
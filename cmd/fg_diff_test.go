@@ -0,0 +1,35 @@
+package cmd
+
+import "testing"
+
+func TestFitsType(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{"bigint", "bigint", true},
+		{"BIGINT", "bigint", true},
+		{"bigint", "int", true},
+		{"bigint", "double", true},
+		{"bigint", "decimal(10,2)", true},
+		{"double", "bigint", false},
+		{"double", "float", true},
+		{"date", "timestamp", true},
+		{"timestamp", "date", false},
+		{"string", "bigint", false},
+		{"array<bigint>", "array<double>", true},
+		{"array<double>", "array<bigint>", false},
+		{"array<array<bigint>>", "array<array<double>>", true},
+		{"array<bigint>", "bigint", false},
+		// malformed types must not panic
+		{"array<", "array<", true},
+		{"array<", "array<bigint>", false},
+		{"array<bigint>", "array<", false},
+		{"array<bigint", "array<double>", false},
+	}
+	for _, tt := range tests {
+		if got := fitsType(tt.from, tt.to); got != tt.want {
+			t.Errorf("fitsType(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
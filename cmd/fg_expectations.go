@@ -0,0 +1,568 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
+	"github.com/MagicLex/hopsworks-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fgExpAttach     string
+	fgExpRemove     bool
+	fgExpPolicy     string
+	fgValidationsID int
+	fgValidateFile  string
+)
+
+// fgExpPolicies maps --policy to the backend's validation ingestion policy.
+var fgExpPolicies = map[string]string{"always": "ALWAYS", "strict": "STRICT"}
+
+var fgExpectationsCmd = &cobra.Command{
+	Use:   "expectations <name>",
+	Short: "List, attach or remove a feature group's expectation suite",
+	Long: `Show the Great Expectations suite attached to a feature group, attach one
+from a suite JSON file (as written by GE), or remove it.
+
+Inserts are validated against the attached suite. With --policy strict, data
+that fails validation is rejected; with always it is written and the report
+records the failure. Without --policy, replacing a suite keeps its policy and a
+new suite gets always.
+
+Examples:
+  hops fg expectations transactions
+  hops fg expectations transactions --attach suite.json --policy strict
+  hops fg expectations transactions --remove`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if fgExpAttach != "" && fgExpRemove {
+			return fmt.Errorf("--attach and --remove can't be combined")
+		}
+		policy, ok := fgExpPolicies[strings.ToLower(fgExpPolicy)]
+		if fgExpPolicy != "" && !ok {
+			return fmt.Errorf("invalid --policy %q: want always or strict", fgExpPolicy)
+		}
+
+		c, err := mustClient()
+		if err != nil {
+			return err
+		}
+
+		fg, err := getFeatureGroupVersion(c, args[0], fgVersion)
+		if err != nil {
+			return err
+		}
+		suite, err := c.GetExpectationSuite(fg.ID)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case fgExpRemove:
+			if suite == nil {
+				return fmt.Errorf("'%s' v%d has no expectation suite", fg.Name, fg.Version)
+			}
+			if err := c.DeleteExpectationSuite(fg.ID, suite.ID); err != nil {
+				return err
+			}
+			if output.JSONMode {
+				output.PrintJSON(suite)
+				return nil
+			}
+			output.Success("Removed expectation suite '%s' from '%s' v%d", suite.ExpectationSuiteName, fg.Name, fg.Version)
+			return nil
+
+		case fgExpAttach != "":
+			newSuite, err := readSuiteFile(fgExpAttach, fg)
+			if err != nil {
+				return err
+			}
+			// Without --policy, keep the replaced suite's policy
+			switch {
+			case policy != "":
+			case suite != nil && suite.ValidationIngestionPolicy != "":
+				policy = suite.ValidationIngestionPolicy
+			default:
+				policy = fgExpPolicies["always"]
+			}
+			newSuite.ValidationIngestionPolicy = policy
+			// Attaching over a suite updates it in place; a failed update leaves it as it was
+			var created *client.ExpectationSuite
+			if suite != nil {
+				newSuite.ID = suite.ID
+				created, err = c.UpdateExpectationSuite(fg.ID, newSuite)
+				if err != nil {
+					return fmt.Errorf("replace suite '%s' (left unchanged): %w", suite.ExpectationSuiteName, err)
+				}
+			} else if created, err = c.CreateExpectationSuite(fg.ID, newSuite); err != nil {
+				return err
+			}
+			if output.JSONMode {
+				output.PrintJSON(created)
+				return nil
+			}
+			if suite != nil {
+				output.Info("Replaced suite '%s'", suite.ExpectationSuiteName)
+			}
+			output.Success("Attached expectation suite '%s' (%d expectations, policy %s) to '%s' v%d",
+				created.ExpectationSuiteName, len(created.Expectations), created.ValidationIngestionPolicy, fg.Name, fg.Version)
+			return nil
+		}
+
+		if suite == nil {
+			if output.JSONMode {
+				output.PrintJSON(&client.ExpectationSuite{})
+				return nil
+			}
+			output.Info("No expectation suite attached to '%s' v%d. Attach one with --attach suite.json", fg.Name, fg.Version)
+			return nil
+		}
+		if output.JSONMode {
+			output.PrintJSON(suite)
+			return nil
+		}
+
+		validation := "on"
+		if !suite.RunValidation {
+			validation = "off"
+		}
+		output.Info("Suite '%s' on '%s' v%d (policy %s, validation on insert %s)",
+			suite.ExpectationSuiteName, fg.Name, fg.Version, suite.ValidationIngestionPolicy, validation)
+		var rows []output.Row
+		for _, e := range suite.Expectations {
+			var kwargs map[string]interface{}
+			json.Unmarshal([]byte(e.Kwargs), &kwargs)
+			column, _ := kwargs["column"].(string)
+			delete(kwargs, "column")
+			rows = append(rows, output.Row{e.ExpectationType, column, formatKwargs(kwargs)})
+		}
+		output.Table([]string{"EXPECTATION", "COLUMN", "KWARGS"}, rows)
+		return nil
+	},
+}
+
+// geSuiteFile is a Great Expectations suite as GE saves it: expectation_suite_name
+// and expectation_type before GE 1.0, name and type since.
+type geSuiteFile struct {
+	Name         string `json:"expectation_suite_name"`
+	NameV1       string `json:"name"`
+	Expectations []struct {
+		Type   string                 `json:"expectation_type"`
+		TypeV1 string                 `json:"type"`
+		Kwargs map[string]interface{} `json:"kwargs"`
+		Meta   map[string]interface{} `json:"meta"`
+	} `json:"expectations"`
+	Meta          map[string]interface{} `json:"meta"`
+	DataAssetType string                 `json:"data_asset_type"`
+}
+
+// readSuiteFile loads a GE suite JSON file as a suite for fg, checking that
+// every column it names is a feature of fg.
+func readSuiteFile(path string, fg *client.FeatureGroup) (*client.ExpectationSuite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f geSuiteFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	suite := &client.ExpectationSuite{
+		ExpectationSuiteName: f.Name,
+		Expectations:         []client.Expectation{},
+		Meta:                 jsonString(f.Meta),
+		DataAssetType:        f.DataAssetType,
+		RunValidation:        true,
+	}
+	if suite.ExpectationSuiteName == "" {
+		suite.ExpectationSuiteName = f.NameV1
+	}
+	if suite.ExpectationSuiteName == "" {
+		suite.ExpectationSuiteName = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if len(f.Expectations) == 0 {
+		return nil, fmt.Errorf("%s: no expectations", path)
+	}
+
+	for i, e := range f.Expectations {
+		typ := e.Type
+		if typ == "" {
+			typ = e.TypeV1
+		}
+		if typ == "" {
+			return nil, fmt.Errorf("%s: expectation %d has no type", path, i+1)
+		}
+		var columns []string
+		if col, ok := e.Kwargs["column"].(string); ok {
+			columns = append(columns, col)
+		}
+		for _, key := range []string{"column_A", "column_B", "column_list"} {
+			switch v := e.Kwargs[key].(type) {
+			case string:
+				columns = append(columns, v)
+			case []interface{}:
+				for _, col := range v {
+					if s, ok := col.(string); ok {
+						columns = append(columns, s)
+					}
+				}
+			}
+		}
+		for _, col := range columns {
+			if findFeature(fg.Features, col) < 0 {
+				return nil, fmt.Errorf("%s: %s: column '%s' is not a feature of '%s' v%d", path, typ, col, fg.Name, fg.Version)
+			}
+		}
+		suite.Expectations = append(suite.Expectations, client.Expectation{
+			ExpectationType: typ,
+			Kwargs:          jsonString(e.Kwargs),
+			Meta:            jsonString(e.Meta),
+		})
+	}
+	return suite, nil
+}
+
+// jsonString encodes v for the API's JSON-in-a-string fields; nil is {}.
+func jsonString(v map[string]interface{}) string {
+	if v == nil {
+		return "{}"
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// formatKwargs renders expectation arguments as k=v, sorted by key.
+func formatKwargs(kwargs map[string]interface{}) string {
+	keys := make([]string, 0, len(kwargs))
+	for k := range kwargs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		v, _ := json.Marshal(kwargs[k])
+		parts[i] = k + "=" + string(v)
+	}
+	return strings.Join(parts, " ")
+}
+
+// expectationResult is one expectation's outcome in a validation.
+type expectationResult struct {
+	Expectation     string                 `json:"expectation"`
+	Kwargs          map[string]interface{} `json:"kwargs,omitempty"`
+	Success         bool                   `json:"success"`
+	Observed        interface{}            `json:"observed,omitempty"`
+	UnexpectedCount *int64                 `json:"unexpectedCount,omitempty"`
+	Exception       string                 `json:"exception,omitempty"`
+}
+
+// reportResults decodes the GE JSON stored in a report's results.
+func reportResults(r client.ValidationReport) []expectationResult {
+	results := make([]expectationResult, 0, len(r.Results))
+	for _, res := range r.Results {
+		var config struct {
+			Type   string                 `json:"expectation_type"`
+			Kwargs map[string]interface{} `json:"kwargs"`
+		}
+		json.Unmarshal([]byte(res.ExpectationConfig), &config)
+		var result struct {
+			Observed        interface{} `json:"observed_value"`
+			UnexpectedCount *int64      `json:"unexpected_count"`
+		}
+		json.Unmarshal([]byte(res.Result), &result)
+		var exception struct {
+			Raised  bool   `json:"raised_exception"`
+			Message string `json:"exception_message"`
+		}
+		json.Unmarshal([]byte(res.ExceptionInfo), &exception)
+
+		er := expectationResult{
+			Expectation:     config.Type,
+			Kwargs:          config.Kwargs,
+			Success:         res.Success,
+			Observed:        result.Observed,
+			UnexpectedCount: result.UnexpectedCount,
+		}
+		if exception.Raised {
+			er.Exception = exception.Message
+		}
+		results = append(results, er)
+	}
+	return results
+}
+
+func expectationColumn(kwargs map[string]interface{}) string {
+	col, _ := kwargs["column"].(string)
+	return col
+}
+
+func printExpectationResults(results []expectationResult) {
+	rows := make([]output.Row, len(results))
+	for i, r := range results {
+		status := "pass"
+		if !r.Success {
+			status = "FAIL"
+		}
+		var observed interface{}
+		switch {
+		case r.Exception != "":
+			observed = "error: " + r.Exception
+		case r.UnexpectedCount != nil:
+			observed = fmt.Sprintf("%d unexpected", *r.UnexpectedCount)
+		case r.Observed != nil:
+			observed = r.Observed
+		}
+		rows[i] = output.Row{r.Expectation, expectationColumn(r.Kwargs), status, observed}
+	}
+	output.Table([]string{"EXPECTATION", "COLUMN", "RESULT", "OBSERVED"}, rows)
+}
+
+var fgValidationColumns = []output.Column[client.ValidationReport]{
+	{Name: "VALIDATED", Value: func(r client.ValidationReport) interface{} { return apiTime(r.ValidationTime) }},
+	{Name: "SUCCESS", Value: func(r client.ValidationReport) interface{} { return r.Success }},
+	{Name: "PASSED", Value: func(r client.ValidationReport) interface{} {
+		var passed int
+		for _, res := range r.Results {
+			if res.Success {
+				passed++
+			}
+		}
+		return fmt.Sprintf("%d/%d", passed, len(r.Results))
+	}},
+	{Name: "FAILED", Flex: true, Value: func(r client.ValidationReport) interface{} {
+		var failed []string
+		for _, res := range reportResults(r) {
+			if !res.Success {
+				label := res.Expectation
+				if col := expectationColumn(res.Kwargs); col != "" {
+					label += "(" + col + ")"
+				}
+				failed = append(failed, label)
+			}
+		}
+		return strings.Join(failed, ", ")
+	}},
+	{Name: "INGESTION", Value: func(r client.ValidationReport) interface{} { return r.IngestionResult }},
+	{Name: "ID", Wide: true, Value: func(r client.ValidationReport) interface{} { return r.ID }},
+}
+
+var fgValidationsCmd = &cobra.Command{
+	Use:   "validations <name>",
+	Short: "List a feature group's validation reports",
+	Long: `List the validation reports recorded on insert, newest first, with the
+expectations that failed. --report shows every expectation of one report.
+
+Examples:
+  hops fg validations transactions
+  hops fg validations transactions --limit 5 --wide
+  hops fg validations transactions --report 42`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := mustClient()
+		if err != nil {
+			return err
+		}
+
+		fg, err := getFeatureGroupVersion(c, args[0], fgVersion)
+		if err != nil {
+			return err
+		}
+
+		if fgValidationsID > 0 {
+			reports, err := c.ListValidationReports(fg.ID, client.Page{})
+			if err != nil {
+				return err
+			}
+			for _, r := range reports {
+				if r.ID != fgValidationsID {
+					continue
+				}
+				if output.JSONMode {
+					output.PrintJSON(&r)
+					return nil
+				}
+				outcome := "passed"
+				if !r.Success {
+					outcome = "failed"
+				}
+				validated := apiTime(r.ValidationTime)
+				if t, ok := validated.(time.Time); ok {
+					validated = t.Format("2006-01-02 15:04:05")
+				}
+				output.Info("Report %d on '%s' v%d: %s (%v), %s", r.ID, fg.Name, fg.Version, outcome, validated, r.IngestionResult)
+				printExpectationResults(reportResults(r))
+				return nil
+			}
+			return fmt.Errorf("'%s' v%d has no validation report %d", fg.Name, fg.Version, fgValidationsID)
+		}
+
+		reports, err := c.ListValidationReports(fg.ID, listPage())
+		if err != nil {
+			return err
+		}
+		reports = trimPage(reports)
+
+		if len(reports) == 0 && !output.JSONMode {
+			output.Info("No validation reports for '%s' v%d", fg.Name, fg.Version)
+			return nil
+		}
+		return printList(reports, fgValidationColumns)
+	},
+}
+
+// validateResult is the JSON form of 'hops fg validate'.
+type validateResult struct {
+	FeatureGroup string              `json:"featureGroup"`
+	Version      int                 `json:"version"`
+	Suite        string              `json:"suite"`
+	File         string              `json:"file"`
+	Rows         int                 `json:"rows"`
+	Success      bool                `json:"success"`
+	Results      []expectationResult `json:"results"`
+}
+
+var fgValidateCmd = &cobra.Command{
+	Use:   "validate <name>",
+	Short: "Validate a local file against the feature group's expectation suite",
+	Long: `Run the expectation suite attached to a feature group against a local file,
+without inserting it or saving a report. Exits 1 if any expectation fails, so
+it can gate 'fg insert' in a pipeline.
+
+Reads .csv, .tsv, .json, .ndjson/.jsonl and .parquet (Python SDK, Great
+Expectations).
+
+Examples:
+  hops fg validate transactions --file data.csv
+  hops fg validate transactions --file data.parquet --json
+  hops fg validate transactions --file data.csv && hops fg insert transactions --file data.csv`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if fgValidateFile == "" {
+			return fmt.Errorf("--file is required")
+		}
+		if _, err := os.Stat(fgValidateFile); err != nil {
+			return err
+		}
+		reader, err := pandasReader(fgValidateFile)
+		if err != nil {
+			return err
+		}
+
+		c, err := mustClient()
+		if err != nil {
+			return err
+		}
+
+		fg, err := getFeatureGroupVersion(c, args[0], fgVersion)
+		if err != nil {
+			return err
+		}
+		suite, err := c.GetExpectationSuite(fg.ID)
+		if err != nil {
+			return err
+		}
+		if suite == nil {
+			return fmt.Errorf("'%s' v%d has no expectation suite (attach one with hops fg expectations %s --attach suite.json)",
+				fg.Name, fg.Version, fg.Name)
+		}
+
+		result := &validateResult{FeatureGroup: fg.Name, Version: fg.Version, Suite: suite.ExpectationSuiteName, File: fgValidateFile}
+		output.Info("Validating %s against suite '%s' (%d expectations)...", fgValidateFile, suite.ExpectationSuiteName, len(suite.Expectations))
+		if err := captureJSON(buildValidateScript(fg, fgValidateFile, reader), result); err != nil {
+			return fmt.Errorf("validate: %w", err)
+		}
+
+		var failed int
+		for _, r := range result.Results {
+			if !r.Success {
+				failed++
+			}
+		}
+		if output.JSONMode {
+			output.PrintJSON(result)
+		} else {
+			printExpectationResults(result.Results)
+		}
+		if !result.Success {
+			return fmt.Errorf("%d of %d expectations failed on %s (%d rows)", failed, len(result.Results), fgValidateFile, result.Rows)
+		}
+		output.Success("All %d expectations passed on %s (%d rows)", len(result.Results), fgValidateFile, result.Rows)
+		return nil
+	},
+}
+
+// pandasReader returns the pandas call that loads path, by extension.
+func pandasReader(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return "pd.read_csv(file_path)", nil
+	case ".tsv":
+		return "pd.read_csv(file_path, sep='\\t')", nil
+	case ".json":
+		return "pd.read_json(file_path)", nil
+	case ".ndjson", ".jsonl":
+		return "pd.read_json(file_path, lines=True)", nil
+	case ".parquet":
+		return "pd.read_parquet(file_path)", nil
+	}
+	return "", fmt.Errorf("unsupported file type %q: want .csv, .tsv, .json, .ndjson, .jsonl or .parquet", filepath.Ext(path))
+}
+
+func buildValidateScript(fg *client.FeatureGroup, path, reader string) string {
+	var sb strings.Builder
+	sb.WriteString(`import hopsworks, warnings, logging, json, sys
+import pandas as pd
+warnings.filterwarnings("ignore")
+logging.getLogger("hsfs").setLevel(logging.WARNING)
+logging.getLogger("hopsworks").setLevel(logging.WARNING)
+
+project = hopsworks.login()
+fs = project.get_feature_store()
+`)
+	sb.WriteString(fmt.Sprintf("fg = fs.get_feature_group(%q, version=%d)\n", fg.Name, fg.Version))
+	sb.WriteString(fmt.Sprintf("file_path = %q\n", path))
+	sb.WriteString(fmt.Sprintf("df = %s\n", reader))
+	sb.WriteString(`report = fg.validate(df, save_report=False, ingestion_result="EXPERIMENT").to_json_dict()
+
+results = []
+for res in report.get("results", []):
+    config = res.get("expectation_config") or {}
+    result = res.get("result") or {}
+    exc = res.get("exception_info") or {}
+    results.append({
+        "expectation": config.get("expectation_type") or config.get("type"),
+        "kwargs": config.get("kwargs") or {},
+        "success": bool(res.get("success")),
+        "observed": result.get("observed_value"),
+        "unexpectedCount": result.get("unexpected_count"),
+        "exception": exc.get("exception_message") if exc.get("raised_exception") else None,
+    })
+print(json.dumps({"success": bool(report.get("success")), "rows": len(df), "results": results}, default=str))
+`)
+	return sb.String()
+}
+
+func init() {
+	fgExpectationsCmd.Flags().IntVar(&fgVersion, "version", 0, "Feature group version (latest if omitted)")
+	fgExpectationsCmd.Flags().StringVar(&fgExpAttach, "attach", "", "Attach the suite in this GE JSON file (replaces the current one)")
+	fgExpectationsCmd.Flags().BoolVar(&fgExpRemove, "remove", false, "Remove the attached suite")
+	fgExpectationsCmd.Flags().StringVar(&fgExpPolicy, "policy", "", "Ingestion policy for --attach: always (write and report) or strict (reject failing data); default keeps the replaced suite's, else always")
+
+	fgValidationsCmd.Flags().IntVar(&fgVersion, "version", 0, "Feature group version (latest if omitted)")
+	fgValidationsCmd.Flags().IntVar(&fgValidationsID, "report", 0, "Show every expectation of this report ID")
+	addListFlags(fgValidationsCmd)
+
+	fgValidateCmd.Flags().IntVar(&fgVersion, "version", 0, "Feature group version (latest if omitted)")
+	fgValidateCmd.Flags().StringVar(&fgValidateFile, "file", "", "Data file to validate (.csv, .tsv, .json, .ndjson, .parquet)")
+
+	fgCmd.AddCommand(fgExpectationsCmd)
+	fgCmd.AddCommand(fgValidationsCmd)
+	fgCmd.AddCommand(fgValidateCmd)
+}
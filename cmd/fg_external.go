@@ -2,8 +2,7 @@ package cmd
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
+	"io"
 	"strings"
 
 	"github.com/MagicLex/hopsworks-cli/pkg/client"
@@ -24,6 +23,14 @@ var (
 	fgExtSchema      string
 )
 
+// externalFGResult is the JSON form of 'hops fg create-external'.
+type externalFGResult struct {
+	Status       string `json:"status"`
+	FeatureGroup string `json:"feature_group"`
+	Connector    string `json:"connector"`
+	Type         string `json:"type"`
+}
+
 var fgCreateExternalCmd = &cobra.Command{
 	Use:   "create-external <name>",
 	Short: "Create an external feature group backed by a storage connector",
@@ -146,21 +153,22 @@ Examples:
 		pyScript := buildExternalFGScript(fgName, fgExtConnector, fgExtQuery,
 			pks, features, fgExtEventTime, fgExtOnline, fgExtDescription)
 
-		pyCmd := exec.Command("python3", "-c", pyScript)
-		pyCmd.Stdout = os.Stdout
-		pyCmd.Stderr = os.Stderr
-		pyCmd.Env = os.Environ()
-
-		if err := pyCmd.Run(); err != nil {
+		err = withTokenRetry(func(stderr io.Writer) error {
+			pyCmd := pythonCmd(pyScript)
+			pyCmd.Stdout = scriptStdout()
+			pyCmd.Stderr = stderr
+			return pyCmd.Run()
+		})
+		if err != nil {
 			return fmt.Errorf("create external feature group: %w", err)
 		}
 
 		if output.JSONMode {
-			output.PrintJSON(map[string]interface{}{
-				"status":        "success",
-				"feature_group": fgName,
-				"connector":     sc.Name,
-				"type":          "external",
+			output.PrintJSON(&externalFGResult{
+				Status:       "success",
+				FeatureGroup: fgName,
+				Connector:    sc.Name,
+				Type:         "external",
 			})
 		}
 		return nil
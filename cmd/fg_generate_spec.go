@@ -0,0 +1,553 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
+	"github.com/MagicLex/hopsworks-cli/pkg/output"
+	"gopkg.in/yaml.v3"
+)
+
+// generateSpec is the layout of a --generate-spec .yaml: how to generate
+// each column. Features left out keep the default generators.
+type generateSpec struct {
+	Rows    int                    `yaml:"rows"`
+	Seed    *int64                 `yaml:"seed"`
+	Columns map[string]*columnSpec `yaml:"columns"`
+}
+
+type columnSpec struct {
+	Distribution string        `yaml:"distribution"` // normal, uniform, zipf, categorical
+	Mean         *float64      `yaml:"mean"`
+	Std          *float64      `yaml:"std"`
+	Min          *float64      `yaml:"min"`
+	Max          *float64      `yaml:"max"`
+	Exponent     *float64      `yaml:"exponent"` // zipf
+	Values       []interface{} `yaml:"values"`   // categorical
+	Weights      []float64     `yaml:"weights"`
+	Cardinality  int           `yaml:"cardinality"`
+	NullRate     float64       `yaml:"null_rate"`
+	Ref          string        `yaml:"ref"` // <fg>[:<version>].<feature>
+	Start        string        `yaml:"start"`
+	End          string        `yaml:"end"`
+
+	// resolved by loadGenerateSpec
+	feature    *client.Feature
+	refFG      *client.FeatureGroup
+	refFeature string
+	start, end time.Time
+}
+
+// generatePlan is what --dry-run prints for a spec: the generator of each
+// feature.
+type generatePlan struct {
+	FeatureGroup string            `json:"featureGroup"`
+	Version      int               `json:"version"`
+	Rows         int               `json:"rows"`
+	Seed         int64             `json:"seed"`
+	Columns      []generatedColumn `json:"columns"`
+}
+
+type generatedColumn struct {
+	Feature   string  `json:"feature"`
+	Type      string  `json:"type"`
+	Generator string  `json:"generator"`
+	NullRate  float64 `json:"nullRate,omitempty"`
+}
+
+const defaultGenerateSeed = 42
+
+var specTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// loadGenerateSpec reads a spec and checks it against the feature group:
+// every column must be a feature, each generator must suit the feature type,
+// and references must name the primary key of an existing feature group.
+// rows, when positive, overrides the spec's row count.
+func loadGenerateSpec(c *client.Client, fg *client.FeatureGroup, path string, rows int) (*generateSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var spec generateSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if rows > 0 {
+		spec.Rows = rows
+	}
+
+	var problems []string
+	if spec.Rows <= 0 {
+		problems = append(problems, "rows must be positive (set rows: or pass --generate N)")
+	}
+	names := make([]string, 0, len(spec.Columns))
+	for name := range spec.Columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	// Keys match features case-insensitively, so two keys can name one feature
+	specFor := make(map[string]string)
+	for _, name := range names {
+		cs := spec.Columns[name]
+		if cs == nil {
+			cs = &columnSpec{}
+			spec.Columns[name] = cs
+		}
+		cs.feature = matchFeature(fg.Features, name)
+		if cs.feature == nil {
+			problems = append(problems, fmt.Sprintf("'%s' is not a feature of '%s' v%d", name, fg.Name, fg.Version))
+			continue
+		}
+		if other, ok := specFor[cs.feature.Name]; ok {
+			problems = append(problems, fmt.Sprintf("'%s' and '%s' both set feature '%s'", other, name, cs.feature.Name))
+			continue
+		}
+		specFor[cs.feature.Name] = name
+		for _, p := range checkColumnSpec(c, cs, fg.EventTime) {
+			problems = append(problems, fmt.Sprintf("'%s': %s", name, p))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%s does not fit '%s' v%d:\n  %s", path, fg.Name, fg.Version, strings.Join(problems, "\n  "))
+	}
+	return &spec, nil
+}
+
+// checkColumnSpec validates one column against its feature and resolves
+// its reference and time range.
+func checkColumnSpec(c *client.Client, cs *columnSpec, eventTime string) []string {
+	var problems []string
+	bad := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+	typ := strings.ToLower(cs.feature.Type)
+	numeric := isIntegerType(typ) || isFloatType(typ)
+	timeType := typ == "timestamp" || typ == "date"
+
+	if cs.NullRate < 0 || cs.NullRate > 1 {
+		bad("null_rate must be between 0 and 1")
+	}
+	if cs.NullRate > 0 && (cs.feature.Primary || cs.feature.Name == eventTime) {
+		bad("null_rate is not allowed on a primary key or the event time")
+	}
+	if cs.Cardinality < 0 {
+		bad("cardinality must be positive")
+	}
+	if cs.feature.Primary && (cs.Distribution != "" || cs.Cardinality > 0 || cs.Start != "") {
+		bad("primary key values must be unique: leave it to the default sequence or use ref")
+	}
+
+	if cs.Ref != "" {
+		if cs.Distribution != "" || cs.Values != nil || cs.Start != "" {
+			bad("ref can't be combined with distribution, values or start/end")
+		}
+		if err := resolveRef(c, cs); err != nil {
+			bad("%v", err)
+		}
+		return problems
+	}
+
+	switch cs.Distribution {
+	case "":
+		if cs.Values != nil || cs.Weights != nil {
+			bad("values and weights need distribution: categorical")
+		}
+	case "normal":
+		if !numeric {
+			bad("normal needs a numeric feature, not %s", typ)
+		}
+		if cs.Std == nil || *cs.Std <= 0 {
+			bad("normal needs std > 0")
+		}
+	case "uniform":
+		if !numeric {
+			bad("uniform needs a numeric feature, not %s (use start/end for times)", typ)
+		}
+		if cs.Min == nil || cs.Max == nil || *cs.Min >= *cs.Max {
+			bad("uniform needs min < max")
+		}
+	case "zipf":
+		if !isIntegerType(typ) && typ != "string" {
+			bad("zipf needs an integer or string feature, not %s", typ)
+		}
+		if cs.Exponent != nil && (*cs.Exponent <= 0 || cs.Cardinality == 0 && *cs.Exponent <= 1) {
+			bad("zipf needs exponent > 1 (> 0 with a cardinality)")
+		}
+	case "categorical":
+		if len(cs.Values) == 0 {
+			bad("categorical needs values")
+		}
+		if cs.Weights != nil && len(cs.Weights) != len(cs.Values) {
+			bad("categorical has %d values but %d weights", len(cs.Values), len(cs.Weights))
+		}
+		sum := 0.0
+		for _, w := range cs.Weights {
+			if w < 0 {
+				bad("weights can't be negative")
+				break
+			}
+			sum += w
+		}
+		if cs.Weights != nil && sum == 0 {
+			bad("weights must not all be zero")
+		}
+		if cs.Cardinality > 0 {
+			bad("cardinality doesn't apply to categorical (the values are the categories)")
+		}
+		for _, v := range cs.Values {
+			if s := specValue(v); !fitsValue(s, typ) {
+				bad("value %q is not a valid %s", s, typ)
+				break
+			}
+		}
+	default:
+		bad("unknown distribution %q (want normal, uniform, zipf or categorical)", cs.Distribution)
+	}
+	if (cs.Min != nil || cs.Max != nil) && cs.Distribution != "normal" && cs.Distribution != "uniform" {
+		bad("min/max apply to normal and uniform only")
+	}
+
+	if cs.Start != "" || cs.End != "" {
+		var err error
+		switch {
+		case !timeType:
+			bad("start/end need a timestamp or date feature, not %s", typ)
+		case cs.Start == "" || cs.End == "":
+			bad("start and end go together")
+		case cs.Distribution != "":
+			bad("start/end can't be combined with %s", cs.Distribution)
+		default:
+			if cs.start, err = parseSpecTime(cs.Start); err != nil {
+				bad("start: %v", err)
+			} else if cs.end, err = parseSpecTime(cs.End); err != nil {
+				bad("end: %v", err)
+			} else if !cs.start.Before(cs.end) {
+				bad("start must be before end")
+			}
+		}
+	}
+	if cs.Cardinality > 0 && cs.Distribution == "" && cs.Start == "" && !numeric && typ != "string" {
+		bad("cardinality needs a distribution or start/end on a %s feature", typ)
+	}
+	return problems
+}
+
+// resolveRef looks up the feature group a ref points to and checks that the
+// referenced feature is a primary key of the same type.
+func resolveRef(c *client.Client, cs *columnSpec) error {
+	target, feature, ok := strings.Cut(cs.Ref, ".")
+	if !ok || target == "" || feature == "" {
+		return fmt.Errorf("ref %q: want <feature group>[:<version>].<feature>", cs.Ref)
+	}
+	name, version := target, 0
+	if n, v, ok := strings.Cut(target, ":"); ok {
+		var err error
+		if version, err = strconv.Atoi(v); err != nil || version <= 0 {
+			return fmt.Errorf("ref %q: bad version %q", cs.Ref, v)
+		}
+		name = n
+	}
+	refFG, err := getFeatureGroupVersion(c, name, version)
+	if err != nil {
+		return fmt.Errorf("ref %q: %w", cs.Ref, err)
+	}
+	i := findFeature(refFG.Features, feature)
+	switch {
+	case i < 0:
+		return fmt.Errorf("ref %q: '%s' v%d has no feature '%s'", cs.Ref, refFG.Name, refFG.Version, feature)
+	case !refFG.Features[i].Primary:
+		return fmt.Errorf("ref %q: '%s' is not a primary key of '%s' v%d", cs.Ref, feature, refFG.Name, refFG.Version)
+	case !loadsAs(refFG.Features[i].Type, cs.feature.Type):
+		return fmt.Errorf("ref %q: %s values don't fit a %s feature", cs.Ref, refFG.Features[i].Type, cs.feature.Type)
+	}
+	cs.refFG, cs.refFeature = refFG, feature
+	return nil
+}
+
+func parseSpecTime(s string) (time.Time, error) {
+	for _, layout := range specTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a date or timestamp", s)
+}
+
+// specValue turns a YAML scalar into the text fitsValue and the script
+// expect; YAML decodes unquoted dates as times.
+func specValue(v interface{}) string {
+	if t, ok := v.(time.Time); ok {
+		if t.Equal(t.Truncate(24 * time.Hour)) {
+			return t.Format("2006-01-02")
+		}
+		return t.Format("2006-01-02 15:04:05")
+	}
+	return fmt.Sprint(v)
+}
+
+func specLiteral(v interface{}, typ string) string {
+	s := specValue(v)
+	if strings.EqualFold(typ, "boolean") {
+		if strings.EqualFold(s, "true") || s == "1" {
+			return "True"
+		}
+		return "False"
+	}
+	return keyLiteral(s, typ)
+}
+
+func floatLiteral(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// specGenerator returns the numpy expression generating n values of a
+// column, and a description for --dry-run. refVar names the Python array
+// holding the referenced values.
+func specGenerator(cs *columnSpec, refVar string) (string, string) {
+	f := *cs.feature
+	typ := strings.ToLower(f.Type)
+	card := cs.Cardinality
+	// pool draws card candidate values, then samples n from them
+	pool := func(expr func(size string) string) string {
+		if card > 0 {
+			return fmt.Sprintf("pick(%s)", expr(strconv.Itoa(card)))
+		}
+		return expr("n")
+	}
+	described := func(d string) string {
+		if card > 0 {
+			return fmt.Sprintf("%s, %d distinct", d, card)
+		}
+		return d
+	}
+
+	switch {
+	case cs.refFG != nil:
+		d := fmt.Sprintf("ref %s v%d.%s", cs.refFG.Name, cs.refFG.Version, cs.refFeature)
+		if f.Primary {
+			return fmt.Sprintf("rng.choice(%s, n, replace=False)", refVar), d + ", unique"
+		}
+		if card > 0 {
+			return fmt.Sprintf("pick(rng.choice(%s, min(%d, len(%s)), replace=False))", refVar, card, refVar), described(d)
+		}
+		return fmt.Sprintf("pick(%s)", refVar), d
+
+	case cs.Distribution == "normal":
+		mean := 0.0
+		if cs.Mean != nil {
+			mean = *cs.Mean
+		}
+		expr := pool(func(size string) string {
+			e := fmt.Sprintf("rng.normal(%s, %s, %s)", floatLiteral(mean), floatLiteral(*cs.Std), size)
+			if cs.Min != nil || cs.Max != nil {
+				e = fmt.Sprintf("np.clip(%s, %s, %s)", e, optFloat(cs.Min), optFloat(cs.Max))
+			}
+			if isIntegerType(typ) {
+				e = fmt.Sprintf(`np.round(%s).astype("int64")`, e)
+			}
+			return e
+		})
+		d := fmt.Sprintf("normal(mean=%s, std=%s)", floatLiteral(mean), floatLiteral(*cs.Std))
+		if cs.Min != nil {
+			d += ", min " + floatLiteral(*cs.Min)
+		}
+		if cs.Max != nil {
+			d += ", max " + floatLiteral(*cs.Max)
+		}
+		return expr, described(d)
+
+	case cs.Distribution == "uniform":
+		lo, hi := floatLiteral(*cs.Min), floatLiteral(*cs.Max)
+		expr := pool(func(size string) string {
+			if isIntegerType(typ) {
+				return fmt.Sprintf("rng.integers(%d, %d, %s, endpoint=True)", int64(*cs.Min), int64(*cs.Max), size)
+			}
+			return fmt.Sprintf("rng.uniform(%s, %s, %s)", lo, hi, size)
+		})
+		return expr, described(fmt.Sprintf("uniform(%s, %s)", lo, hi))
+
+	case cs.Distribution == "zipf":
+		a := 1.5
+		if cs.Exponent != nil {
+			a = *cs.Exponent
+		}
+		expr := fmt.Sprintf("zipf(%s, %d)", floatLiteral(a), card)
+		if typ == "string" {
+			expr = fmt.Sprintf("[f%q for r in %s]", f.Name+"_{r}", expr)
+		}
+		return expr, described(fmt.Sprintf("zipf(exponent=%s)", floatLiteral(a)))
+
+	case cs.Distribution == "categorical":
+		lits := make([]string, len(cs.Values))
+		for i, v := range cs.Values {
+			lits[i] = specLiteral(v, typ)
+		}
+		p := "None"
+		if cs.Weights != nil {
+			sum := 0.0
+			for _, w := range cs.Weights {
+				sum += w
+			}
+			ws := make([]string, len(cs.Weights))
+			for i, w := range cs.Weights {
+				ws[i] = floatLiteral(w / sum)
+			}
+			p = "[" + strings.Join(ws, ", ") + "]"
+		}
+		expr := fmt.Sprintf("pick([%s], p=%s)", strings.Join(lits, ", "), p)
+		switch typ {
+		case "timestamp":
+			expr = fmt.Sprintf("pd.to_datetime(%s)", expr)
+		case "date":
+			expr = fmt.Sprintf("pd.to_datetime(%s).date", expr)
+		}
+		d := fmt.Sprintf("categorical, %d values", len(cs.Values))
+		if cs.Weights != nil {
+			d += ", weighted"
+		}
+		return expr, d
+
+	case cs.Start != "":
+		expr := pool(func(size string) string {
+			return fmt.Sprintf(`pd.to_datetime(rng.integers(%d, %d, %s, endpoint=True), unit="s")`, cs.start.Unix(), cs.end.Unix(), size)
+		})
+		if typ == "date" {
+			expr = fmt.Sprintf("pd.to_datetime(%s).date", expr)
+		}
+		return expr, described(fmt.Sprintf("%s to %s", cs.Start, cs.End))
+
+	case card > 0:
+		switch {
+		case isIntegerType(typ):
+			return fmt.Sprintf("rng.integers(1, %d, n, endpoint=True)", card), fmt.Sprintf("1..%d", card)
+		case isFloatType(typ):
+			return fmt.Sprintf("pick(np.round(rng.uniform(1.0, 1000.0, %d), 2))", card), described("uniform(1, 1000)")
+		default:
+			return fmt.Sprintf("[f%q for i in rng.integers(1, %d, n, endpoint=True)]", f.Name+"_{i}", card), fmt.Sprintf("%s_1..%s_%d", f.Name, f.Name, card)
+		}
+	}
+	return columnGenerator(f), "default"
+}
+
+func optFloat(f *float64) string {
+	if f == nil {
+		return "None"
+	}
+	return floatLiteral(*f)
+}
+
+// specPlan describes, per feature, what the spec generates.
+func specPlan(fg *client.FeatureGroup, spec *generateSpec) *generatePlan {
+	plan := &generatePlan{FeatureGroup: fg.Name, Version: fg.Version, Rows: spec.Rows, Seed: spec.seed()}
+	for _, f := range fg.Features {
+		col := generatedColumn{Feature: f.Name, Type: f.Type, Generator: "default"}
+		if cs := spec.column(f.Name); cs != nil {
+			_, col.Generator = specGenerator(cs, "")
+			col.NullRate = cs.NullRate
+		} else if f.Primary {
+			col.Generator = "sequence 1..n"
+		}
+		plan.Columns = append(plan.Columns, col)
+	}
+	return plan
+}
+
+func printGeneratePlan(plan *generatePlan) {
+	output.Info("'%s' v%d: %d rows, seed %d", plan.FeatureGroup, plan.Version, plan.Rows, plan.Seed)
+	rows := make([]output.Row, len(plan.Columns))
+	for i, col := range plan.Columns {
+		nulls := "-"
+		if col.NullRate > 0 {
+			nulls = fmt.Sprintf("%.0f%%", col.NullRate*100)
+		}
+		rows[i] = output.Row{col.Feature, col.Type, col.Generator, nulls}
+	}
+	output.Table([]string{"FEATURE", "TYPE", "GENERATOR", "NULLS"}, rows)
+	output.Success("Spec fits '%s' v%d", plan.FeatureGroup, plan.Version)
+}
+
+func (s *generateSpec) seed() int64 {
+	if s.Seed != nil {
+		return *s.Seed
+	}
+	return defaultGenerateSeed
+}
+
+// column returns the spec of a feature, or nil if the spec leaves it out.
+func (s *generateSpec) column(feature string) *columnSpec {
+	for _, cs := range s.Columns {
+		if cs.feature != nil && cs.feature.Name == feature {
+			return cs
+		}
+	}
+	return nil
+}
+
+// specGenerators returns the Python that reads referenced values and sets
+// up the helpers, and the generator expression of each feature.
+func specGenerators(fg *client.FeatureGroup, spec *generateSpec) (string, []string) {
+	var setup strings.Builder
+	setup.WriteString(fmt.Sprintf(`rng = np.random.default_rng(%d)
+
+def pick(pool, p=None):
+    pool = np.asarray(pool)
+    return pool[rng.choice(len(pool), n, p=p)]
+
+def zipf(a, k):
+    if k == 0:
+        return rng.zipf(a, n)
+    w = 1.0 / np.arange(1, k + 1) ** a
+    return rng.choice(np.arange(1, k + 1), n, p=w / w.sum())
+`, spec.seed()))
+
+	gens := make([]string, len(fg.Features))
+	refs := 0
+	for i, f := range fg.Features {
+		cs := spec.column(f.Name)
+		if cs == nil {
+			gens[i] = columnGenerator(f)
+			continue
+		}
+		refVar := ""
+		if cs.refFG != nil {
+			refVar = fmt.Sprintf("ref_%d", refs)
+			refs++
+			setup.WriteString(fmt.Sprintf(`
+%s = fs.get_feature_group(%q, version=%d).select([%q]).read()[%q].dropna().unique()
+if len(%s) == 0:
+    sys.exit("'%s' v%d has no rows to reference")
+`, refVar, cs.refFG.Name, cs.refFG.Version, cs.refFeature, cs.refFeature, refVar, cs.refFG.Name, cs.refFG.Version))
+			if f.Primary {
+				setup.WriteString(fmt.Sprintf("if len(%s) < n:\n    sys.exit(f\"'%s' v%d has {len(%s)} keys, fewer than the {n} rows to generate\")\n",
+					refVar, cs.refFG.Name, cs.refFG.Version, refVar))
+			}
+		}
+		gens[i], _ = specGenerator(cs, refVar)
+	}
+	return setup.String(), gens
+}
+
+// specNulls returns the Python that blanks out each column's null_rate.
+func specNulls(fg *client.FeatureGroup, spec *generateSpec) string {
+	var sb strings.Builder
+	for _, f := range fg.Features {
+		cs := spec.column(f.Name)
+		if cs == nil || cs.NullRate == 0 {
+			continue
+		}
+		col := fmt.Sprintf("df[%q]", f.Name)
+		switch typ := strings.ToLower(f.Type); {
+		case isIntegerType(typ):
+			col += fmt.Sprintf(".astype(%q)", nullableInts[typ])
+		case typ == "boolean":
+			col += `.astype("boolean")`
+		}
+		sb.WriteString(fmt.Sprintf("df[%q] = %s.mask(rng.random(n) < %s)\n", f.Name, col, floatLiteral(cs.NullRate)))
+	}
+	return sb.String()
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
+	"gopkg.in/yaml.v3"
+)
+
+func specTestFeatures() []client.Feature {
+	return []client.Feature{
+		{Name: "id", Type: "bigint", Primary: true},
+		{Name: "ts", Type: "timestamp"},
+		{Name: "amount", Type: "double"},
+		{Name: "count", Type: "int"},
+		{Name: "country", Type: "string"},
+		{Name: "day", Type: "date"},
+		{Name: "flag", Type: "boolean"},
+	}
+}
+
+// parseColumnSpec decodes a flow-style column spec for feature.
+func parseColumnSpec(t *testing.T, feature, spec string) *columnSpec {
+	t.Helper()
+	var cs columnSpec
+	if err := yaml.Unmarshal([]byte(spec), &cs); err != nil {
+		t.Fatalf("parse %s: %v", spec, err)
+	}
+	cs.feature = matchFeature(specTestFeatures(), feature)
+	if cs.feature == nil {
+		t.Fatalf("no feature %s", feature)
+	}
+	return &cs
+}
+
+func TestCheckColumnSpec(t *testing.T) {
+	tests := []struct {
+		feature string
+		spec    string
+		want    string // a problem to expect, "" for none
+	}{
+		{"amount", "{distribution: normal, mean: 50, std: 10, min: 0}", ""},
+		{"count", "{distribution: uniform, min: 1, max: 10}", ""},
+		{"count", "{distribution: zipf, exponent: 0.5, cardinality: 10}", ""},
+		{"country", "{distribution: categorical, values: [SE, DE], weights: [3, 1]}", ""},
+		{"ts", "{start: 2024-01-01, end: '2024-06-30 12:00:00'}", ""},
+		{"country", "{cardinality: 5, null_rate: 0.1}", ""},
+
+		// Distribution vs. type
+		{"country", "{distribution: normal, std: 1}", "normal needs a numeric feature, not string"},
+		{"ts", "{distribution: uniform, min: 0, max: 1}", "use start/end for times"},
+		{"amount", "{distribution: zipf}", "zipf needs an integer or string feature, not double"},
+		{"amount", "{distribution: poisson}", `unknown distribution "poisson"`},
+		{"count", "{distribution: categorical, values: [1, abc]}", `value "abc" is not a valid int`},
+
+		// Distribution parameters
+		{"amount", "{distribution: normal}", "normal needs std > 0"},
+		{"amount", "{distribution: uniform, min: 5, max: 5}", "uniform needs min < max"},
+		{"count", "{distribution: zipf, exponent: 1}", "zipf needs exponent > 1"},
+		{"amount", "{distribution: categorical, values: [1], min: 0}", "min/max apply to normal and uniform only"},
+
+		// Categorical values and weights
+		{"country", "{values: [SE]}", "values and weights need distribution: categorical"},
+		{"country", "{distribution: categorical}", "categorical needs values"},
+		{"country", "{distribution: categorical, values: [SE, DE], weights: [1]}", "categorical has 2 values but 1 weights"},
+		{"country", "{distribution: categorical, values: [SE, DE], weights: [1, -1]}", "weights can't be negative"},
+		{"country", "{distribution: categorical, values: [SE, DE], weights: [0, 0]}", "weights must not all be zero"},
+
+		// start/end
+		{"amount", "{start: 2024-01-01, end: 2024-02-01}", "start/end need a timestamp or date feature, not double"},
+		{"ts", "{start: 2024-01-01}", "start and end go together"},
+		{"ts", "{start: 2024-02-01, end: 2024-01-01}", "start must be before end"},
+		{"ts", "{start: yesterday, end: 2024-01-01}", `start: "yesterday" is not a date or timestamp`},
+		{"ts", "{distribution: categorical, values: [2024-01-01], start: 2024-01-01, end: 2024-02-01}", "start/end can't be combined with categorical"},
+
+		// null_rate
+		{"amount", "{null_rate: 1.5}", "null_rate must be between 0 and 1"},
+		{"id", "{null_rate: 0.1}", "null_rate is not allowed on a primary key or the event time"},
+		{"ts", "{null_rate: 0.1}", "null_rate is not allowed on a primary key or the event time"},
+
+		// cardinality
+		{"amount", "{cardinality: -1}", "cardinality must be positive"},
+		{"country", "{distribution: categorical, values: [SE], cardinality: 2}", "cardinality doesn't apply to categorical"},
+		{"flag", "{cardinality: 2}", "cardinality needs a distribution or start/end on a boolean feature"},
+
+		// Primary key uniqueness
+		{"id", "{distribution: uniform, min: 1, max: 100}", "primary key values must be unique"},
+		{"id", "{cardinality: 10}", "primary key values must be unique"},
+		{"id", "{ref: customers, distribution: normal}", "ref can't be combined with distribution"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.feature+" "+tt.spec, func(t *testing.T) {
+			cs := parseColumnSpec(t, tt.feature, tt.spec)
+			problems := checkColumnSpec(nil, cs, "ts")
+			if tt.want == "" {
+				if len(problems) > 0 {
+					t.Errorf("unexpected problems: %v", problems)
+				}
+				return
+			}
+			for _, p := range problems {
+				if strings.Contains(p, tt.want) {
+					return
+				}
+			}
+			t.Errorf("problems %q, want one containing %q", problems, tt.want)
+		})
+	}
+}
+
+func TestSpecGenerator(t *testing.T) {
+	tests := []struct {
+		feature string
+		spec    string
+		expr    string
+		desc    string
+	}{
+		{"amount", "{distribution: normal, mean: 50, std: 10}",
+			"rng.normal(50, 10, n)", "normal(mean=50, std=10)"},
+		{"count", "{distribution: normal, std: 2.5, min: 0, cardinality: 20}",
+			`pick(np.round(np.clip(rng.normal(0, 2.5, 20), 0, None)).astype("int64"))`, "normal(mean=0, std=2.5), min 0, 20 distinct"},
+		{"count", "{distribution: uniform, min: 1, max: 10}",
+			"rng.integers(1, 10, n, endpoint=True)", "uniform(1, 10)"},
+		{"amount", "{distribution: uniform, min: 0.5, max: 9.5}",
+			"rng.uniform(0.5, 9.5, n)", "uniform(0.5, 9.5)"},
+		{"count", "{distribution: zipf}",
+			"zipf(1.5, 0)", "zipf(exponent=1.5)"},
+		{"country", "{distribution: zipf, exponent: 0.8, cardinality: 50}",
+			`[f"country_{r}" for r in zipf(0.8, 50)]`, "zipf(exponent=0.8), 50 distinct"},
+		{"country", "{distribution: categorical, values: [SE, DE], weights: [3, 1]}",
+			`pick(["SE", "DE"], p=[0.75, 0.25])`, "categorical, 2 values, weighted"},
+		{"flag", "{distribution: categorical, values: [true, false]}",
+			"pick([True, False], p=None)", "categorical, 2 values"},
+		{"day", "{distribution: categorical, values: [2024-01-01]}",
+			`pd.to_datetime(pick(["2024-01-01"], p=None)).date`, "categorical, 1 values"},
+		{"ts", "{start: 2024-01-01, end: 2024-01-02}",
+			`pd.to_datetime(rng.integers(1704067200, 1704153600, n, endpoint=True), unit="s")`, "2024-01-01 to 2024-01-02"},
+		{"count", "{cardinality: 7}",
+			"rng.integers(1, 7, n, endpoint=True)", "1..7"},
+		{"country", "{cardinality: 3}",
+			`[f"country_{i}" for i in rng.integers(1, 3, n, endpoint=True)]`, "country_1..country_3"},
+		{"amount", "{null_rate: 0.2}",
+			"np.round(np.random.uniform(1.0, 1000.0, n), 2).tolist()", "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.feature+" "+tt.spec, func(t *testing.T) {
+			cs := parseColumnSpec(t, tt.feature, tt.spec)
+			if problems := checkColumnSpec(nil, cs, "ts"); len(problems) > 0 {
+				t.Fatalf("spec rejected: %v", problems)
+			}
+			expr, desc := specGenerator(cs, "ref_0")
+			if expr != tt.expr {
+				t.Errorf("expr\n got %s\nwant %s", expr, tt.expr)
+			}
+			if desc != tt.desc {
+				t.Errorf("description = %q, want %q", desc, tt.desc)
+			}
+		})
+	}
+
+	// References resolve against the cluster, so set the result directly
+	ref := &client.FeatureGroup{Name: "customers", Version: 2}
+	refTests := []struct {
+		feature string
+		card    int
+		expr    string
+		desc    string
+	}{
+		{"id", 0, "rng.choice(ref_0, n, replace=False)", "ref customers v2.id, unique"},
+		{"count", 0, "pick(ref_0)", "ref customers v2.id"},
+		{"count", 5, "pick(rng.choice(ref_0, min(5, len(ref_0)), replace=False))", "ref customers v2.id, 5 distinct"},
+	}
+	for _, tt := range refTests {
+		cs := parseColumnSpec(t, tt.feature, "{ref: customers:2.id}")
+		cs.Cardinality, cs.refFG, cs.refFeature = tt.card, ref, "id"
+		expr, desc := specGenerator(cs, "ref_0")
+		if expr != tt.expr || desc != tt.desc {
+			t.Errorf("ref on %s: got %s (%s), want %s (%s)", tt.feature, expr, desc, tt.expr, tt.desc)
+		}
+	}
+}
+
+func TestLoadGenerateSpecKeys(t *testing.T) {
+	fg := &client.FeatureGroup{Name: "transactions", Version: 1, EventTime: "ts", Features: specTestFeatures()}
+	tests := []struct {
+		spec string
+		want string // "" for a valid spec
+	}{
+		{"rows: 10\ncolumns:\n  Amount: {distribution: normal, std: 1}\n", ""},
+		{"rows: 10\ncolumns:\n  Amount: {distribution: normal, std: 1}\n  amount: {null_rate: 0.1}\n",
+			"'Amount' and 'amount' both set feature 'amount'"},
+		{"rows: 10\ncolumns:\n  missing: {}\n", "'missing' is not a feature of 'transactions' v1"},
+		{"columns:\n  amount: {}\n", "rows must be positive"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "spec.yaml")
+		if err := os.WriteFile(path, []byte(tt.spec), 0644); err != nil {
+			t.Fatal(err)
+		}
+		spec, err := loadGenerateSpec(nil, fg, path, 0)
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("%q: %v", tt.spec, err)
+		case tt.want == "" && spec.column("amount") == nil:
+			t.Errorf("%q: no spec for amount", tt.spec)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("%q: error = %v, want it to contain %q", tt.spec, err, tt.want)
+		}
+	}
+}
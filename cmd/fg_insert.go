@@ -2,8 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/MagicLex/hopsworks-cli/pkg/client"
@@ -15,25 +16,108 @@ var (
 	fgInsertFile     string
 	fgInsertGenerate int
 	fgInsertOnline   bool
+	fgInsertDryRun   bool
+	fgInsertCoerce   bool
+	fgInsertNoCheck  bool
+	fgInsertChunk    int
+	fgInsertResume   bool
+	fgInsertSpec     string
 )
 
+// insertResult is the JSON form of 'hops fg insert'.
+type insertResult struct {
+	Status        string `json:"status"`
+	FeatureGroup  string `json:"feature_group"`
+	Version       int    `json:"version"`
+	RowsGenerated int    `json:"rows_generated,omitempty"`
+}
+
 var fgInsertCmd = &cobra.Command{
 	Use:   "insert <name>",
 	Short: "Insert data into a feature group",
-	Long: `Insert data into a feature group from a JSON file, stdin, or generate sample data.
+	Long: `Insert data into a feature group from a file, stdin, or generate sample data.
+
+A --file (CSV, TSV, JSON, NDJSON or Parquet) is checked against the feature
+group schema before anything is written: missing or extra columns, values
+that don't convert to the feature type, null or duplicate primary keys and
+unparseable event times. Parquet is checked from its footer only (types,
+row count, null counts): its values and duplicate keys aren't checked, and
+--coerce doesn't apply. Any problem stops the insert.
+
+--generate-spec describes the generated data per column in a .yaml:
+distribution (normal, uniform, zipf, categorical with weights), cardinality,
+null_rate, start/end time ranges, and ref to sample another feature group's
+primary key values. Unlisted features keep the default generators; the seed
+makes runs repeatable. The spec is checked against the schema first.
+
+  rows: 10000
+  seed: 7
+  columns:
+    customer_id: {ref: "customers.customer_id"}
+    amount:      {distribution: normal, mean: 80, std: 25, min: 0}
+    channel:     {distribution: categorical, values: [web, store, app], weights: [6, 3, 1]}
+    product_id:  {distribution: zipf, exponent: 1.3, cardinality: 500}
+    ts:          {start: "2024-01-01", end: "2024-06-30"}
+    coupon:      {cardinality: 20, null_rate: 0.8}
+
+Large files can be inserted in chunks (--chunk-size) instead of being read
+into memory at once. Finished chunks are recorded in a checkpoint under
+~/.hops/checkpoints, so after a failure --resume skips them (and doesn't
+check them again). The duplicate-key check holds at most 1000000 keys.
 
 Examples:
   # Generate and insert 50 rows of sample data
   hops fg insert customer_transactions --generate 50
 
+  # Generate realistic data from a spec (check it first with --dry-run)
+  hops fg insert transactions --generate-spec spec.yaml --dry-run
+  hops fg insert transactions --generate-spec spec.yaml --generate 1000000
+
   # Insert from a JSON file
   hops fg insert customer_transactions --file data.json
 
+  # Only check a file against the schema
+  hops fg insert customer_transactions --file data.csv --dry-run
+
+  # Cast columns that hold the right values in the wrong type (e.g. "42")
+  hops fg insert customer_transactions --file data.csv --coerce
+
+  # Stream a multi-GB export in chunks; after a failure, pick up where it stopped
+  hops fg insert customer_transactions --file big.csv --chunk-size 500000
+  hops fg insert customer_transactions --file big.csv --resume
+
   # Insert from stdin (pipe)
   cat data.json | hops fg insert customer_transactions`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		fgName := args[0]
+		if fgInsertSpec != "" && fgInsertFile != "" {
+			return fmt.Errorf("--generate-spec and --file can't be combined")
+		}
+		if fgInsertDryRun && fgInsertFile == "" && fgInsertSpec == "" {
+			return fmt.Errorf("--dry-run needs --file or --generate-spec")
+		}
+		if fgInsertCoerce && fgInsertFile == "" {
+			return fmt.Errorf("--coerce needs --file")
+		}
+		if fgInsertCoerce && strings.EqualFold(filepath.Ext(fgInsertFile), ".parquet") {
+			return fmt.Errorf("--coerce can't be used with Parquet: only the footer is read, so values can't be checked before a cast; write the columns with the feature types instead")
+		}
+		if fgInsertNoCheck && (fgInsertDryRun || fgInsertCoerce) {
+			return fmt.Errorf("--no-check can't be combined with --dry-run or --coerce")
+		}
+		if fgInsertChunk < 0 {
+			return fmt.Errorf("--chunk-size must be positive")
+		}
+		chunked := fgInsertChunk > 0 || fgInsertResume
+		if chunked && (fgInsertFile == "" || fgInsertGenerate > 0) {
+			return fmt.Errorf("--chunk-size and --resume need --file")
+		}
+		if chunked {
+			if err := checkChunkable(fgInsertFile); err != nil {
+				return err
+			}
+		}
 
 		// Get FG info first via the Go client to validate it exists
 		c, err := mustClient()
@@ -41,54 +125,107 @@ Examples:
 			return err
 		}
 
-		fg, err := c.GetFeatureGroup(fgName, fgVersion)
+		fg, err := getFeatureGroupVersion(c, fgName, fgVersion)
 		if err != nil {
-			return fmt.Errorf("feature group '%s' not found: %w", fgName, err)
+			return err
+		}
+
+		// A resumed insert only checks the rows still to go
+		var cp *insertCheckpoint
+		var cpPath string
+		if chunked {
+			if cp, cpPath, err = chunkedCheckpoint(fg, fgInsertFile, fgInsertChunk, fgInsertResume); err != nil {
+				return err
+			}
+		}
+
+		// Check the file before handing it to Python
+		var coerce string
+		var rows int64
+		if fgInsertFile != "" && fgInsertGenerate == 0 && !fgInsertNoCheck {
+			check, err := checkInsertFile(fg, fgInsertFile, fgInsertCoerce, cp)
+			if err != nil {
+				return err
+			}
+			if fgInsertDryRun || !check.Valid {
+				if output.JSONMode {
+					output.PrintJSON(check)
+				} else {
+					printInsertCheck(check, fg.Features)
+				}
+			}
+			if !check.Valid {
+				return fmt.Errorf("%s does not match '%s' v%d: %d problem(s), nothing inserted",
+					fgInsertFile, fg.Name, fg.Version, len(check.Problems))
+			}
+			if fgInsertDryRun {
+				return nil
+			}
+			if check.SkippedRows > 0 {
+				output.Info("Checked %s: %d rows still to insert match '%s' v%d", fgInsertFile, check.Rows, fg.Name, fg.Version)
+			} else {
+				output.Info("Checked %s: %d rows match '%s' v%d", fgInsertFile, check.Rows, fg.Name, fg.Version)
+			}
+			coerce = coerceSnippet(check)
+			rows = check.Rows + check.SkippedRows
+		}
+
+		if chunked {
+			return insertChunked(fg, fgInsertFile, cp, cpPath, rows, coerce, fgInsertOnline)
 		}
 
 		// Build the Python script
 		var pyScript string
-		if fgInsertGenerate > 0 {
+		rowsGenerated := fgInsertGenerate
+		if fgInsertSpec != "" {
+			spec, err := loadGenerateSpec(c, fg, fgInsertSpec, fgInsertGenerate)
+			if err != nil {
+				return err
+			}
+			if fgInsertDryRun {
+				plan := specPlan(fg, spec)
+				if output.JSONMode {
+					output.PrintJSON(plan)
+				} else {
+					printGeneratePlan(plan)
+				}
+				return nil
+			}
+			setup, gens := specGenerators(fg, spec)
+			pyScript = generateScript(fg.Name, fg.Version, fg.Features, spec.Rows, spec.seed(), setup, gens, specNulls(fg, spec), fgInsertOnline)
+			rowsGenerated = spec.Rows
+		} else if fgInsertGenerate > 0 {
 			pyScript = buildGenerateScript(fg.Name, fg.Version, fg.Features, fgInsertGenerate, fgInsertOnline)
 		} else if fgInsertFile != "" {
-			pyScript = buildFileInsertScript(fg.Name, fg.Version, fgInsertFile, fgInsertOnline)
+			pyScript = buildFileInsertScript(fg.Name, fg.Version, fgInsertFile, coerce, fgInsertOnline)
 		} else {
 			// Read from stdin
 			pyScript = buildStdinInsertScript(fg.Name, fg.Version, fgInsertOnline)
 		}
 
-		if !output.JSONMode {
-			output.Info("Inserting data into '%s' v%d (ID: %d)...", fg.Name, fg.Version, fg.ID)
-		}
-
 		// Execute via python3
-		pyCmd := exec.Command("python3", "-c", pyScript)
-		pyCmd.Stdout = os.Stdout
-		pyCmd.Stderr = os.Stderr
-		pyCmd.Stdin = os.Stdin
-
-		// Set env vars for hops-deltalake mTLS (PEM certs + HDFS user identity).
-		// PEMS_DIR must point to PEM files extracted from the pod's JKS keystores.
-		// See docs/fixes/sdk-fixes.md for the full chain and why these are needed.
-		pyCmd.Env = append(os.Environ(),
-			"PEMS_DIR="+os.ExpandEnv("${HOME}/.hopsfs_pems"),
-			"LIBHDFS_DEFAULT_USER="+os.Getenv("HADOOP_USER_NAME"),
-		)
-
-		if err := pyCmd.Run(); err != nil {
+		p := output.StartProgress(fmt.Sprintf("Inserting data into '%s' v%d (ID: %d)", fg.Name, fg.Version, fg.ID))
+		// pythonCmd sets the env vars for hops-deltalake mTLS (PEM certs + HDFS user
+		// identity). See docs/fixes/sdk-fixes.md for the full chain and why these are needed.
+		err = withTokenRetry(func(stderr io.Writer) error {
+			pyCmd := pythonCmd(pyScript)
+			pyCmd.Stdout = p.Wrap(scriptStdout())
+			pyCmd.Stderr = p.Wrap(stderr)
+			pyCmd.Stdin = os.Stdin
+			return pyCmd.Run()
+		})
+		p.Done(err)
+		if err != nil {
 			return fmt.Errorf("insert into feature group: %w", err)
 		}
 
 		if output.JSONMode {
-			out := map[string]interface{}{
-				"status":        "success",
-				"feature_group": fg.Name,
-				"version":       fg.Version,
-			}
-			if fgInsertGenerate > 0 {
-				out["rows_generated"] = fgInsertGenerate
-			}
-			output.PrintJSON(out)
+			output.PrintJSON(&insertResult{
+				Status:        "success",
+				FeatureGroup:  fg.Name,
+				Version:       fg.Version,
+				RowsGenerated: rowsGenerated,
+			})
 		}
 		return nil
 	},
@@ -113,11 +250,20 @@ func storageSnippet(onlineOnly bool) string {
 // buildGenerateScript creates a Python script that generates sample data
 // based on the feature group schema and inserts it.
 func buildGenerateScript(fgName string, fgVersion int, features []client.Feature, n int, onlineOnly bool) string {
+	gens := make([]string, len(features))
+	for i, f := range features {
+		gens[i] = columnGenerator(f)
+	}
+	return generateScript(fgName, fgVersion, features, n, defaultGenerateSeed, "", gens, "", onlineOnly)
+}
+
+// generateScript inserts n generated rows: gens holds the expression of each
+// feature, setup runs before them and post on the DataFrame df.
+func generateScript(fgName string, fgVersion int, features []client.Feature, n int, seed int64, setup string, gens []string, post string, onlineOnly bool) string {
 	// Build column generators based on type
 	var colGens []string
-	for _, f := range features {
-		gen := columnGenerator(f)
-		colGens = append(colGens, fmt.Sprintf("    %q: %s,", f.Name, gen))
+	for i, f := range features {
+		colGens = append(colGens, fmt.Sprintf("    %q: %s,", f.Name, gens[i]))
 	}
 
 	// Build PK list and event time for get_or_create
@@ -146,12 +292,13 @@ import hopsworks
 import pandas as pd
 import numpy as np
 from datetime import datetime, timedelta
+import sys
 import warnings, logging
 warnings.filterwarnings("ignore")
 logging.getLogger("hsfs").setLevel(logging.WARNING)
 logging.getLogger("hopsworks").setLevel(logging.WARNING)
 
-np.random.seed(42)
+np.random.seed(%d)
 n = %d
 
 project = hopsworks.login()
@@ -165,15 +312,15 @@ fg = fs.get_or_create_feature_group(
 %s
     online_enabled=True,
 )
-
+%s
 data = {
 %s
 }
 df = pd.DataFrame(data)
-print(f"Generated {len(df)} rows, inserting...")
+%sprint(f"Generated {len(df)} rows, inserting...")
 fg.insert(df, write_options=%s%s)
 print(f"Successfully inserted {len(df)} rows into {fg.name} v{fg.version}")
-`, n, fgName, fgVersion, pkList, etLine, strings.Join(colGens, "\n"), writeOptionsSnippet(onlineOnly), storageSnippet(onlineOnly))
+`, seed, n, fgName, fgVersion, pkList, etLine, setup, strings.Join(colGens, "\n"), post, writeOptionsSnippet(onlineOnly), storageSnippet(onlineOnly))
 }
 
 // columnGenerator returns a numpy expression to generate sample data for a feature type.
@@ -215,7 +362,9 @@ func columnGenerator(f client.Feature) string {
 	return "np.random.randint(1, 100, n).tolist()"
 }
 
-func buildFileInsertScript(fgName string, fgVersion int, filePath string, onlineOnly bool) string {
+// buildFileInsertScript reads the file into a DataFrame, applies the casts
+// from coerceSnippet, and inserts it.
+func buildFileInsertScript(fgName string, fgVersion int, filePath, coerce string, onlineOnly bool) string {
 	return fmt.Sprintf(`
 import hopsworks
 import pandas as pd
@@ -228,10 +377,15 @@ fs = project.get_feature_store()
 fg = fs.get_or_create_feature_group(name=%q, version=%d)
 
 file_path = %q
-if file_path.endswith('.csv'):
+lower = file_path.lower()
+if lower.endswith('.csv'):
     df = pd.read_csv(file_path)
-elif file_path.endswith('.parquet'):
+elif lower.endswith('.tsv'):
+    df = pd.read_csv(file_path, sep='\t')
+elif lower.endswith('.parquet'):
     df = pd.read_parquet(file_path)
+elif lower.endswith(('.ndjson', '.jsonl')):
+    df = pd.read_json(file_path, lines=True)
 else:
     with open(file_path) as f:
         data = json.load(f)
@@ -239,11 +393,11 @@ else:
         df = pd.DataFrame(data)
     else:
         df = pd.DataFrame([data])
-
+%s
 print(f"Read {len(df)} rows from {file_path}, inserting...")
 fg.insert(df, write_options=%s%s)
 print(f"Successfully inserted {len(df)} rows into {fg.name} v{fg.version}")
-`, fgName, fgVersion, filePath, writeOptionsSnippet(onlineOnly), storageSnippet(onlineOnly))
+`, fgName, fgVersion, filePath, coerce, writeOptionsSnippet(onlineOnly), storageSnippet(onlineOnly))
 }
 
 func buildStdinInsertScript(fgName string, fgVersion int, onlineOnly bool) string {
@@ -274,8 +428,14 @@ print(f"Successfully inserted {len(df)} rows into {fg.name} v{fg.version}")
 
 func init() {
 	fgInsertCmd.Flags().IntVar(&fgVersion, "version", 0, "Feature group version (latest if omitted)")
-	fgInsertCmd.Flags().StringVar(&fgInsertFile, "file", "", "JSON/CSV/Parquet file to insert")
+	fgInsertCmd.Flags().StringVar(&fgInsertFile, "file", "", "CSV, TSV, JSON, NDJSON or Parquet file to insert")
+	fgInsertCmd.Flags().BoolVar(&fgInsertDryRun, "dry-run", false, "Check --file against the schema and stop")
+	fgInsertCmd.Flags().BoolVar(&fgInsertCoerce, "coerce", false, "Cast columns whose values all convert to the feature type (not Parquet)")
+	fgInsertCmd.Flags().BoolVar(&fgInsertNoCheck, "no-check", false, "Skip the schema check of --file")
+	fgInsertCmd.Flags().IntVar(&fgInsertChunk, "chunk-size", 0, "Insert --file in chunks of N rows instead of all at once")
+	fgInsertCmd.Flags().BoolVar(&fgInsertResume, "resume", false, "Skip the chunks a failed --chunk-size run already inserted")
 	fgInsertCmd.Flags().IntVar(&fgInsertGenerate, "generate", 0, "Generate N rows of sample data")
+	fgInsertCmd.Flags().StringVar(&fgInsertSpec, "generate-spec", "", "Generate data as described per column in a .yaml spec")
 	fgInsertCmd.Flags().BoolVar(&fgInsertOnline, "online-only", false, "Write to online store only (skip offline materialization)")
 	fgCmd.AddCommand(fgInsertCmd)
 }
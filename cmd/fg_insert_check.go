@@ -0,0 +1,542 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
+	"github.com/MagicLex/hopsworks-cli/pkg/infer"
+	"github.com/MagicLex/hopsworks-cli/pkg/output"
+)
+
+// insertCheck is the validation of a data file against a feature group's
+// schema, run by 'hops fg insert' before anything is written.
+type insertCheck struct {
+	FeatureGroup  string          `json:"featureGroup"`
+	Version       int             `json:"version"`
+	File          string          `json:"file"`
+	Rows          int64           `json:"rows"`
+	SkippedRows   int64           `json:"skippedRows,omitempty"` // inserted by an earlier run, not checked again
+	Columns       []columnCheck   `json:"columns"`
+	Missing       []string        `json:"missingColumns"` // features absent from the file
+	Extra         []string        `json:"extraColumns"`   // file columns that are not features
+	RaggedRows    int64           `json:"raggedRows,omitempty"`
+	PrimaryKey    []string        `json:"primaryKey"`
+	NullKeys      int64           `json:"nullPrimaryKeys"`
+	DuplicateKeys int64           `json:"duplicatePrimaryKeys"`
+	EventTime     *eventTimeCheck `json:"eventTime,omitempty"`
+	NotChecked    []string        `json:"notChecked,omitempty"`
+	Problems      []string        `json:"problems"`
+	Valid         bool            `json:"valid"`
+}
+
+// columnCheck is one file column matched to a feature. Status is ok (loads
+// as is), coercible (every value casts to the feature type) or invalid.
+type columnCheck struct {
+	Column   string `json:"column"`
+	Feature  string `json:"feature"`
+	Type     string `json:"type"`
+	FileType string `json:"fileType"`
+	Nulls    int64  `json:"nulls"`
+	Invalid  int64  `json:"invalid"`
+	Example  string `json:"example,omitempty"` // first invalid value
+	Status   string `json:"status"`
+	Coerced  bool   `json:"coerced,omitempty"`
+}
+
+type eventTimeCheck struct {
+	Feature     string `json:"feature"`
+	Nulls       int64  `json:"nulls"`
+	Unparseable int64  `json:"unparseable"`
+}
+
+// maxKeyCheck caps how many distinct primary keys the duplicate check holds
+// in memory; past it, later keys aren't checked.
+const maxKeyCheck = 1_000_000
+
+// checkInsertFile validates a CSV, TSV, JSON, NDJSON or Parquet file against
+// the feature group. Parquet is checked from its footer only: types, row
+// count and null counts, not the values themselves. Rows in chunks that cp
+// (nil if not resuming) records as inserted are skipped.
+func checkInsertFile(fg *client.FeatureGroup, path string, coerce bool, cp *insertCheckpoint) (*insertCheck, error) {
+	check := &insertCheck{
+		FeatureGroup: fg.Name,
+		Version:      fg.Version,
+		File:         path,
+		Columns:      []columnCheck{},
+		Missing:      []string{},
+		Extra:        []string{},
+		PrimaryKey:   primaryKeys(fg.Features),
+		Problems:     []string{},
+	}
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".parquet":
+		err = checkParquet(check, fg, path)
+	case ".csv", ".tsv", ".json", ".ndjson", ".jsonl":
+		err = checkRows(check, fg, path, cp)
+	default:
+		err = fmt.Errorf("unsupported file type %q (use .csv, .tsv, .json, .ndjson or .parquet)", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	matched := map[string]bool{}
+	for _, cc := range check.Columns {
+		matched[cc.Feature] = true
+	}
+	for _, f := range fg.Features {
+		if !matched[f.Name] {
+			check.Missing = append(check.Missing, f.Name)
+		}
+	}
+	if fg.EventTime != "" && matched[fg.EventTime] {
+		for _, cc := range check.Columns {
+			if cc.Feature == fg.EventTime {
+				check.EventTime = &eventTimeCheck{Feature: cc.Feature, Nulls: cc.Nulls, Unparseable: cc.Invalid}
+			}
+		}
+	}
+	for i := range check.Columns {
+		check.Columns[i].Coerced = coerce && check.Columns[i].Status == "coercible"
+	}
+	check.Problems = insertProblems(check, fg.EventTime)
+	check.Valid = len(check.Problems) == 0
+	return check, nil
+}
+
+// matchFeature finds the feature a file column feeds. Names are compared
+// case-insensitively: the SDK lowercases column names on insert.
+func matchFeature(features []client.Feature, column string) *client.Feature {
+	for i := range features {
+		if strings.EqualFold(features[i].Name, column) {
+			return &features[i]
+		}
+	}
+	return nil
+}
+
+// columnState accumulates one file column while rows stream by.
+type columnState struct {
+	feature  *client.Feature
+	check    int // index into insertCheck.Columns, -1 for extra columns
+	fileType string
+	nonNull  int64
+}
+
+func checkRows(check *insertCheck, fg *client.FeatureGroup, path string, cp *insertCheckpoint) error {
+	rows, err := infer.OpenRows(path)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	text := strings.EqualFold(filepath.Ext(path), ".csv") || strings.EqualFold(filepath.Ext(path), ".tsv")
+	var states []*columnState
+	keyIdx := make([]int, len(check.PrimaryKey)) // column index per key, -1 until seen
+	for i := range keyIdx {
+		keyIdx[i] = -1
+	}
+	seen := map[string]bool{} // keys seen so far, up to maxKeyCheck of them
+
+	for {
+		row, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%s: row %d: %w", path, check.Rows+check.SkippedRows+1, err)
+		}
+
+		// JSON records can bring new columns at any row
+		for len(states) < len(rows.Columns) {
+			name := rows.Columns[len(states)]
+			st := &columnState{feature: matchFeature(fg.Features, name), check: -1}
+			if st.feature == nil {
+				check.Extra = append(check.Extra, name)
+			} else {
+				st.check = len(check.Columns)
+				check.Columns = append(check.Columns, columnCheck{Column: name, Feature: st.feature.Name, Type: st.feature.Type})
+				for k, pk := range check.PrimaryKey {
+					if pk == st.feature.Name {
+						keyIdx[k] = len(states)
+					}
+				}
+			}
+			states = append(states, st)
+		}
+
+		if cp.inserted(check.Rows + check.SkippedRows) {
+			check.SkippedRows++
+			continue
+		}
+		check.Rows++
+
+		for i, v := range row {
+			st := states[i]
+			if st.feature == nil {
+				continue
+			}
+			var vt string
+			if s, ok := v.(string); ok && text {
+				vt = infer.TextType(s)
+			} else {
+				vt = infer.ValueType(v)
+			}
+			if vt == "" {
+				continue
+			}
+			st.nonNull++
+			st.fileType = infer.Merge(st.fileType, vt)
+			if !fitsValue(v, st.feature.Type) {
+				cc := &check.Columns[st.check]
+				if cc.Invalid == 0 {
+					cc.Example = fmt.Sprint(v)
+				}
+				cc.Invalid++
+			}
+		}
+
+		if len(keyIdx) > 0 {
+			parts := make([]string, len(keyIdx))
+			null := false
+			for k, i := range keyIdx {
+				if i < 0 || i >= len(row) {
+					null = true
+					break
+				}
+				s := strings.TrimSpace(fmt.Sprint(row[i]))
+				if row[i] == nil || text && infer.TextType(s) == "" {
+					null = true
+					break
+				}
+				parts[k] = s
+			}
+			if null {
+				check.NullKeys++
+				continue
+			}
+			switch key := strings.Join(parts, "\x1f"); {
+			case seen[key]:
+				check.DuplicateKeys++
+			case len(seen) < maxKeyCheck:
+				seen[key] = true
+			case !notChecked(check, keysNotChecked):
+				output.Warn("checked the first %d distinct primary keys for duplicates, not the rest", maxKeyCheck)
+				check.NotChecked = append(check.NotChecked, keysNotChecked)
+			}
+		}
+	}
+	check.RaggedRows = rows.Ragged
+	for _, i := range keyIdx {
+		if i < 0 { // a key column is missing altogether, reported as such
+			check.NullKeys, check.DuplicateKeys = 0, 0
+		}
+	}
+
+	for _, st := range states {
+		if st.feature == nil {
+			continue
+		}
+		cc := &check.Columns[st.check]
+		cc.Nulls = check.Rows - st.nonNull
+		cc.FileType = st.fileType
+		switch {
+		case cc.Invalid > 0:
+			cc.Status = "invalid"
+		case st.fileType == "" || loadsAs(st.fileType, cc.Type):
+			cc.Status = "ok"
+		default:
+			cc.Status = "coercible"
+		}
+	}
+	return nil
+}
+
+func checkParquet(check *insertCheck, fg *client.FeatureGroup, path string) error {
+	meta, err := infer.ReadParquetMeta(path)
+	if err != nil {
+		return err
+	}
+	check.Rows = meta.Rows
+	// Parquet values are not decoded
+	check.NotChecked = []string{"value conversions", "duplicate primary keys"}
+	if len(check.PrimaryKey) > 0 {
+		output.Warn("%s: duplicate primary keys are not checked in Parquet files (values are not decoded)", filepath.Base(path))
+	}
+
+	for _, col := range meta.Columns {
+		f := matchFeature(fg.Features, col.Name)
+		if f == nil {
+			check.Extra = append(check.Extra, col.Name)
+			continue
+		}
+		cc := columnCheck{Column: col.Name, Feature: f.Name, Type: f.Type, FileType: col.Type, Status: "ok"}
+		if nulls, ok := meta.Nulls[col.Name]; ok {
+			cc.Nulls = nulls
+		} else {
+			check.NotChecked = append(check.NotChecked, fmt.Sprintf("nulls in '%s' (no statistics)", col.Name))
+		}
+		switch {
+		case loadsAs(col.Type, f.Type):
+		case castsTo(col.Type, f.Type):
+			cc.Status = "coercible"
+		default:
+			cc.Status = "invalid"
+		}
+		if f.Primary {
+			// rows with a null key: exact for one key, a lower bound for several
+			check.NullKeys = max(check.NullKeys, cc.Nulls)
+		}
+		check.Columns = append(check.Columns, cc)
+	}
+	return nil
+}
+
+// insertProblems lists what would make the insert fail or write bad data.
+func insertProblems(check *insertCheck, eventTime string) []string {
+	problems := []string{}
+	if check.Rows == 0 {
+		problems = append(problems, "the file has no rows")
+	}
+	if len(check.Missing) > 0 {
+		problems = append(problems, "missing features: "+strings.Join(check.Missing, ", "))
+	}
+	if len(check.Extra) > 0 {
+		problems = append(problems, "columns that are not features: "+strings.Join(check.Extra, ", "))
+	}
+	if check.RaggedRows > 0 {
+		problems = append(problems, fmt.Sprintf("%d row(s) have a different number of fields than the header", check.RaggedRows))
+	}
+	for _, cc := range check.Columns {
+		switch {
+		case cc.Status == "invalid" && cc.Feature == eventTime && cc.Invalid > 0:
+			problems = append(problems, fmt.Sprintf("event time '%s': %d value(s) don't parse as %s, e.g. %q", cc.Column, cc.Invalid, cc.Type, cc.Example))
+		case cc.Status == "invalid" && cc.Invalid > 0:
+			problems = append(problems, fmt.Sprintf("'%s': %d value(s) don't convert to %s, e.g. %q", cc.Column, cc.Invalid, cc.Type, cc.Example))
+		case cc.Status == "invalid":
+			problems = append(problems, fmt.Sprintf("'%s' is %s in the file but %s in the feature group", cc.Column, cc.FileType, cc.Type))
+		case cc.Status == "coercible" && notChecked(check, "value conversions"):
+			problems = append(problems, fmt.Sprintf("'%s' is %s in the file but %s in the feature group (--coerce can't cast Parquet columns; write it as %s)", cc.Column, cc.FileType, cc.Type, cc.Type))
+		case cc.Status == "coercible" && !cc.Coerced:
+			problems = append(problems, fmt.Sprintf("'%s' is %s in the file but %s in the feature group (--coerce casts it)", cc.Column, cc.FileType, cc.Type))
+		}
+	}
+	if check.NullKeys > 0 {
+		problems = append(problems, fmt.Sprintf("%d row(s) have a null primary key", check.NullKeys))
+	}
+	if check.DuplicateKeys > 0 {
+		problems = append(problems, fmt.Sprintf("%d row(s) repeat a primary key of an earlier row", check.DuplicateKeys))
+	}
+	return problems
+}
+
+// keysNotChecked is the NotChecked entry once the key check is full.
+var keysNotChecked = fmt.Sprintf("duplicate primary keys past the first %d", maxKeyCheck)
+
+func notChecked(check *insertCheck, what string) bool {
+	for _, n := range check.NotChecked {
+		if n == what {
+			return true
+		}
+	}
+	return false
+}
+
+func isIntegerType(t string) bool {
+	return t == "tinyint" || t == "smallint" || t == "int" || t == "bigint"
+}
+
+func isFloatType(t string) bool {
+	return t == "float" || t == "double" || strings.HasPrefix(t, "decimal")
+}
+
+// loadsAs reports whether a column of file type from loads into a feature
+// of type to without a cast. Nested types are compared by kind only.
+func loadsAs(from, to string) bool {
+	from, to = strings.ToLower(from), strings.ToLower(to)
+	switch {
+	case fitsType(from, to):
+		return true
+	case strings.HasPrefix(to, "array<"):
+		return strings.HasPrefix(from, "array<")
+	case strings.HasPrefix(to, "struct<"), strings.HasPrefix(to, "map<"):
+		return strings.HasPrefix(from, "struct<") || strings.HasPrefix(from, "map<")
+	}
+	return false
+}
+
+// castsTo reports whether a Parquet column type casts to a feature type.
+// Parquet values are not decoded, so this goes by type alone.
+func castsTo(from, to string) bool {
+	from, to = strings.ToLower(from), strings.ToLower(to)
+	numeric := func(t string) bool { return isIntegerType(t) || isFloatType(t) }
+	switch {
+	case to == "string":
+		return !strings.Contains(from, "<")
+	case numeric(to):
+		return numeric(from) || from == "string"
+	case to == "boolean":
+		return isIntegerType(from)
+	case to == "timestamp", to == "date":
+		return from == "timestamp" || from == "date" || from == "string" || isIntegerType(from)
+	}
+	return false
+}
+
+// nullableInts are the pandas integer dtypes that hold nulls, per Hive type.
+var nullableInts = map[string]string{"tinyint": "Int8", "smallint": "Int16", "int": "Int32", "bigint": "Int64"}
+
+var intRanges = map[string]float64{"tinyint": math.MaxInt8, "smallint": math.MaxInt16, "int": math.MaxInt32, "bigint": math.MaxInt64}
+
+// fitsValue reports whether a non-null value converts to a feature type,
+// as is or through a trivial cast ("42" to bigint, 1 to boolean, an epoch in
+// milliseconds to timestamp).
+func fitsValue(v interface{}, typ string) bool {
+	typ = strings.ToLower(typ)
+	if typ == "string" || typ == "binary" {
+		return true
+	}
+	// Strings are judged by what their text holds, other values by their type
+	raw := strings.TrimSpace(fmt.Sprint(v))
+	as := infer.ValueType(v)
+	if s, ok := v.(string); ok {
+		raw = strings.TrimSpace(s)
+		as = infer.TextType(s)
+	}
+	number := func() (float64, bool) {
+		f, err := strconv.ParseFloat(raw, 64)
+		return f, err == nil
+	}
+
+	switch {
+	case isIntegerType(typ):
+		f, ok := number()
+		if !ok || (as != "bigint" && as != "double") || f != math.Trunc(f) {
+			return false
+		}
+		return as == "bigint" && typ == "bigint" || math.Abs(f) <= intRanges[typ]
+	case isFloatType(typ):
+		return as == "bigint" || as == "double"
+	case typ == "boolean":
+		if as == "boolean" {
+			return true
+		}
+		f, ok := number()
+		return ok && (f == 0 || f == 1)
+	case typ == "timestamp":
+		return as == "timestamp" || as == "date" || as == "bigint"
+	case typ == "date":
+		return as == "date" || as == "timestamp"
+	case strings.HasPrefix(typ, "array<"):
+		return strings.HasPrefix(as, "array<") || strings.HasPrefix(raw, "[") && json.Valid([]byte(raw))
+	case strings.HasPrefix(typ, "struct<"), strings.HasPrefix(typ, "map<"):
+		return strings.HasPrefix(as, "struct<") || strings.HasPrefix(raw, "{") && json.Valid([]byte(raw))
+	}
+	return true
+}
+
+// coerceSnippet returns the pandas casts for the columns --coerce fixes; the
+// script has the data in df.
+func coerceSnippet(check *insertCheck) string {
+	var sb strings.Builder
+	for _, cc := range check.Columns {
+		if !cc.Coerced {
+			continue
+		}
+		col := fmt.Sprintf("df[%q]", cc.Column)
+		typ := strings.ToLower(cc.Type)
+		var expr string
+		switch {
+		case isIntegerType(typ):
+			expr = fmt.Sprintf(`pd.to_numeric(%s).astype(%q)`, col, nullableInts[typ])
+		case typ == "float":
+			expr = fmt.Sprintf(`pd.to_numeric(%s).astype("float32")`, col)
+		case isFloatType(typ):
+			expr = fmt.Sprintf(`pd.to_numeric(%s).astype("float64")`, col)
+		case typ == "boolean":
+			expr = fmt.Sprintf(`%s.map(lambda v: v if pd.isna(v) else str(v).strip().lower() in ("true", "1", "1.0")).astype("boolean")`, col)
+		case typ == "timestamp" && (isIntegerType(cc.FileType) || isFloatType(cc.FileType)):
+			expr = fmt.Sprintf(`pd.to_datetime(%s, unit="ms", utc=True)`, col)
+		case typ == "timestamp":
+			expr = fmt.Sprintf(`pd.to_datetime(%s, utc=True)`, col)
+		case typ == "date":
+			expr = fmt.Sprintf(`pd.to_datetime(%s).dt.date`, col)
+		case typ == "string":
+			expr = fmt.Sprintf(`%s.map(lambda v: v if pd.isna(v) else str(v))`, col)
+		case strings.Contains(typ, "<"):
+			expr = fmt.Sprintf(`%s.map(lambda v: json.loads(v) if isinstance(v, str) else v)`, col)
+		default:
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s = %s\n", col, expr))
+	}
+	return sb.String()
+}
+
+func printInsertCheck(check *insertCheck, features []client.Feature) {
+	output.Info("Checked %s against '%s' v%d: %d rows, %d columns",
+		check.File, check.FeatureGroup, check.Version, check.Rows, len(check.Columns)+len(check.Extra))
+
+	rows := make([]output.Row, 0, len(check.Columns)+len(check.Missing)+len(check.Extra))
+	for _, cc := range check.Columns {
+		status := cc.Status
+		if cc.Coerced {
+			status = "coerced"
+		}
+		if cc.Example != "" {
+			status += fmt.Sprintf(" (e.g. %q)", cc.Example)
+		}
+		invalid := interface{}(cc.Invalid)
+		if notChecked(check, "value conversions") {
+			invalid = "-"
+		}
+		fileType := cc.FileType
+		if fileType == "" {
+			fileType = "-"
+		}
+		rows = append(rows, output.Row{cc.Column, cc.Type, fileType, cc.Nulls, invalid, status})
+	}
+	for _, name := range check.Missing {
+		rows = append(rows, output.Row{name, features[findFeature(features, name)].Type, "-", "-", "-", "missing"})
+	}
+	for _, name := range check.Extra {
+		rows = append(rows, output.Row{name, "-", "-", "-", "-", "not a feature"})
+	}
+	output.Table([]string{"COLUMN", "FEATURE TYPE", "FILE TYPE", "NULLS", "INVALID", "STATUS"}, rows)
+
+	if len(check.PrimaryKey) > 0 {
+		dups := fmt.Sprintf("%d duplicate", check.DuplicateKeys)
+		if notChecked(check, "duplicate primary keys") {
+			dups = "duplicates not checked"
+		}
+		output.Info("Primary key (%s): %d null, %s", strings.Join(check.PrimaryKey, ", "), check.NullKeys, dups)
+	}
+	if et := check.EventTime; et != nil {
+		if notChecked(check, "value conversions") {
+			output.Info("Event time '%s': %d null", et.Feature, et.Nulls)
+		} else {
+			output.Info("Event time '%s': %d null, %d unparseable", et.Feature, et.Nulls, et.Unparseable)
+		}
+	}
+	if check.SkippedRows > 0 {
+		output.Info("Skipped %d rows an earlier run inserted", check.SkippedRows)
+	}
+	if len(check.NotChecked) > 0 {
+		output.Info("Not checked: %s", strings.Join(check.NotChecked, ", "))
+	}
+
+	if check.Valid {
+		output.Success("Data matches the schema of '%s' v%d", check.FeatureGroup, check.Version)
+		return
+	}
+	for _, p := range check.Problems {
+		output.Error("%s", p)
+	}
+}
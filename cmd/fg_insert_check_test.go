@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
+)
+
+var checkFG = &client.FeatureGroup{
+	Name:    "transactions",
+	Version: 1,
+	Features: []client.Feature{
+		{Name: "id", Type: "bigint", Primary: true},
+		{Name: "amount", Type: "double"},
+	},
+}
+
+func writeSample(t *testing.T, name, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCheckInsertFileRows(t *testing.T) {
+	path := writeSample(t, "tx.csv", "id,amount\n1,1.5\n2,2\n2,x\n,3\n4,4\n")
+	check, err := checkInsertFile(checkFG, path, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if check.Rows != 5 || check.DuplicateKeys != 1 || check.NullKeys != 1 || check.Valid {
+		t.Errorf("rows %d, duplicates %d, null keys %d, valid %v; want 5, 1, 1, false",
+			check.Rows, check.DuplicateKeys, check.NullKeys, check.Valid)
+	}
+	if cc := check.Columns[1]; cc.Invalid != 1 || cc.Example != "x" {
+		t.Errorf("amount: %d invalid (e.g. %q), want 1 (e.g. \"x\")", cc.Invalid, cc.Example)
+	}
+}
+
+func TestCheckInsertFileResume(t *testing.T) {
+	// The bad rows are all in chunk 1, which an earlier run inserted
+	path := writeSample(t, "tx.csv", "id,amount\n1,1\n2,2\n2,x\n,3\n5,5\n6,6\n")
+	cp := &insertCheckpoint{ChunkSize: 2, Chunks: []chunkResult{{Chunk: 1, Rows: 2}}}
+	check, err := checkInsertFile(checkFG, path, false, cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if check.Rows != 4 || check.SkippedRows != 2 || !check.Valid {
+		t.Errorf("rows %d, skipped %d, valid %v (%v); want 4, 2, true",
+			check.Rows, check.SkippedRows, check.Valid, check.Problems)
+	}
+}
+
+func TestCheckpointInserted(t *testing.T) {
+	var none *insertCheckpoint
+	if none.inserted(0) {
+		t.Error("a nil checkpoint has nothing inserted")
+	}
+	cp := &insertCheckpoint{ChunkSize: 10, Chunks: []chunkResult{{Chunk: 0, Rows: 10}, {Chunk: 2, Rows: 10}}}
+	for row, want := range map[int64]bool{0: true, 9: true, 10: false, 19: false, 20: true, 29: true, 30: false} {
+		if got := cp.inserted(row); got != want {
+			t.Errorf("inserted(%d) = %v, want %v", row, got, want)
+		}
+	}
+}
+
+func TestCheckInsertFileParquet(t *testing.T) {
+	fg := &client.FeatureGroup{Name: "events", Version: 1, Features: []client.Feature{
+		{Name: "id", Type: "bigint", Primary: true},
+		{Name: "name", Type: "string"},
+		{Name: "category", Type: "string"},
+		{Name: "score", Type: "string"}, // double in the file
+		{Name: "day", Type: "date"},
+		{Name: "ts", Type: "timestamp"},
+		{Name: "price", Type: "decimal(10,2)"},
+		{Name: "flag", Type: "boolean"},
+		{Name: "small", Type: "int"},
+		{Name: "legacy_ts", Type: "timestamp"},
+	}}
+	check, err := checkInsertFile(fg, filepath.Join("..", "pkg", "infer", "testdata", "flat.parquet"), false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if check.Rows != 6 || check.NullKeys != 0 || check.Valid {
+		t.Errorf("rows %d, null keys %d, valid %v; want 6, 0, false", check.Rows, check.NullKeys, check.Valid)
+	}
+	if len(check.Problems) != 1 || !strings.Contains(check.Problems[0], "--coerce can't cast Parquet") {
+		t.Errorf("problems %q, want one about score that --coerce can't fix", check.Problems)
+	}
+	for _, what := range []string{"duplicate primary keys", "nulls in 'score' (no statistics)", "nulls in 'legacy_ts' (no statistics)"} {
+		if !notChecked(check, what) {
+			t.Errorf("not checked %q, want %q in it", check.NotChecked, what)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
+	"github.com/MagicLex/hopsworks-cli/pkg/config"
+	"github.com/MagicLex/hopsworks-cli/pkg/output"
+)
+
+// chunkMarker prefixes the line the insert script prints after each chunk.
+const chunkMarker = "hops-chunk "
+
+// insertCheckpoint records the chunks of a file already inserted, so that
+// --resume can skip them after a failure. It is dropped once all are in.
+type insertCheckpoint struct {
+	FeatureGroup string        `json:"featureGroup"`
+	Version      int           `json:"version"`
+	File         string        `json:"file"`
+	Size         int64         `json:"size"`
+	ModTime      time.Time     `json:"modTime"`
+	ChunkSize    int           `json:"chunkSize"`
+	Chunks       []chunkResult `json:"chunks"`
+
+	done map[int]bool // chunks of Chunks, built by inserted
+}
+
+// inserted reports whether the data row at index row (from 0) is in a
+// chunk the checkpoint has as inserted. A nil checkpoint has none.
+func (cp *insertCheckpoint) inserted(row int64) bool {
+	if cp == nil || len(cp.Chunks) == 0 {
+		return false
+	}
+	if cp.done == nil {
+		cp.done = make(map[int]bool, len(cp.Chunks))
+		for _, c := range cp.Chunks {
+			cp.done[c.Chunk] = true
+		}
+	}
+	return cp.done[int(row/int64(cp.ChunkSize))]
+}
+
+type chunkResult struct {
+	Chunk   int  `json:"chunk"`
+	Rows    int  `json:"rows"`
+	Resumed bool `json:"resumed,omitempty"` // inserted by an earlier run
+}
+
+// chunkedInsertResult is the JSON form of 'hops fg insert --chunk-size'.
+type chunkedInsertResult struct {
+	FeatureGroup string        `json:"featureGroup"`
+	Version      int           `json:"version"`
+	File         string        `json:"file"`
+	ChunkSize    int           `json:"chunkSize"`
+	Rows         int           `json:"rows"`
+	Chunks       []chunkResult `json:"chunks"`
+}
+
+// checkpointPath is where the checkpoint of inserting file into a feature
+// group version lives: one per file and target, under ~/.hops/checkpoints.
+func checkpointPath(fg *client.FeatureGroup, file string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d\x00%s", fg.Name, fg.Version, file)))
+	return filepath.Join(config.ConfigDir(), "checkpoints", fmt.Sprintf("insert-%x.json", sum[:8]))
+}
+
+func loadCheckpoint(path string) (*insertCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cp insertCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("read checkpoint %s: %w", path, err)
+	}
+	return &cp, nil
+}
+
+func (cp *insertCheckpoint) save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// chunkedCheckpoint returns the checkpoint to continue from: the saved one
+// with --resume (chunkSize 0 takes its chunk size), otherwise a fresh one.
+func chunkedCheckpoint(fg *client.FeatureGroup, file string, chunkSize int, resume bool) (*insertCheckpoint, string, error) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return nil, "", err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return nil, "", err
+	}
+	path := checkpointPath(fg, abs)
+	saved, err := loadCheckpoint(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resume && saved != nil {
+		switch {
+		case saved.Size != info.Size() || !saved.ModTime.Equal(info.ModTime()):
+			return nil, "", fmt.Errorf("%s changed since the checkpoint was written; rerun without --resume to start over", file)
+		case chunkSize > 0 && chunkSize != saved.ChunkSize:
+			return nil, "", fmt.Errorf("the checkpoint was written with --chunk-size %d", saved.ChunkSize)
+		}
+		for i := range saved.Chunks {
+			saved.Chunks[i].Resumed = true
+		}
+		output.Info("Resuming: %d chunk(s) of %d rows each already inserted", len(saved.Chunks), saved.ChunkSize)
+		return saved, path, nil
+	}
+	if resume {
+		if chunkSize == 0 {
+			return nil, "", fmt.Errorf("no checkpoint for %s into '%s' v%d; pass --chunk-size to start", file, fg.Name, fg.Version)
+		}
+		output.Info("No checkpoint for %s, starting from the first chunk", file)
+	} else if saved != nil && len(saved.Chunks) > 0 {
+		output.Warn("discarding the checkpoint of an earlier run (%d chunk(s) done); pass --resume to continue it", len(saved.Chunks))
+	}
+	cp := &insertCheckpoint{
+		FeatureGroup: fg.Name,
+		Version:      fg.Version,
+		File:         abs,
+		Size:         info.Size(),
+		ModTime:      info.ModTime(),
+		ChunkSize:    chunkSize,
+		Chunks:       []chunkResult{},
+	}
+	return cp, path, nil
+}
+
+// checkChunkable rejects files the insert script can't read in chunks.
+func checkChunkable(file string) error {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".csv", ".tsv", ".ndjson", ".jsonl", ".parquet":
+		return nil
+	}
+	return fmt.Errorf("--chunk-size needs a .csv, .tsv, .ndjson or .parquet file (a JSON array can't be streamed)")
+}
+
+// insertChunked streams file into the feature group chunk by chunk from a
+// single Python process. Each finished chunk is written to the checkpoint cp
+// at cpPath; totalRows (0 if unknown) drives the progress bar.
+func insertChunked(fg *client.FeatureGroup, file string, cp *insertCheckpoint, cpPath string, totalRows int64, coerce string, onlineOnly bool) error {
+	var totalChunks int
+	if totalRows > 0 {
+		totalChunks = int((totalRows + int64(cp.ChunkSize) - 1) / int64(cp.ChunkSize))
+	}
+	inserted := func() int {
+		n := 0
+		for _, c := range cp.Chunks {
+			n += c.Rows
+		}
+		return n
+	}
+	state := func() (string, float64) {
+		if totalChunks == 0 {
+			return fmt.Sprintf("%d chunk(s), %d rows", len(cp.Chunks), inserted()), 0
+		}
+		return fmt.Sprintf("%d/%d chunks", len(cp.Chunks), totalChunks), float64(inserted()) / float64(totalRows)
+	}
+
+	p := output.StartProgress(fmt.Sprintf("Inserting %s into '%s' v%d in chunks of %d", filepath.Base(file), fg.Name, fg.Version, cp.ChunkSize))
+	if len(cp.Chunks) > 0 {
+		p.Update(state())
+	}
+	err := withTokenRetry(func(stderr io.Writer) error {
+		// Built per attempt: a retry skips the chunks the failed run finished
+		done := make([]int, len(cp.Chunks))
+		for i, c := range cp.Chunks {
+			done[i] = c.Chunk
+		}
+		script := buildChunkedInsertScript(fg.Name, fg.Version, cp.File, cp.ChunkSize, done, coerce, onlineOnly)
+
+		stdout := &chunkWriter{w: p.Wrap(scriptStdout()), onChunk: func(c chunkResult) {
+			cp.Chunks = append(cp.Chunks, c)
+			if err := cp.save(cpPath); err != nil {
+				output.Warn("could not write checkpoint: %v", err)
+			}
+			p.Update(state())
+		}}
+		pyCmd := pythonCmd(script)
+		pyCmd.Stdout = stdout
+		pyCmd.Stderr = p.Wrap(stderr)
+		err := pyCmd.Run()
+		stdout.Flush()
+		return err
+	})
+	p.Done(err)
+	if err != nil {
+		if len(cp.Chunks) > 0 {
+			return fmt.Errorf("insert into feature group: %w (%d chunk(s) inserted; rerun with --resume to skip them)", err, len(cp.Chunks))
+		}
+		return fmt.Errorf("insert into feature group: %w", err)
+	}
+	if err := os.Remove(cpPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		output.Warn("could not remove checkpoint: %v", err)
+	}
+
+	sort.Slice(cp.Chunks, func(i, j int) bool { return cp.Chunks[i].Chunk < cp.Chunks[j].Chunk })
+	result := &chunkedInsertResult{
+		FeatureGroup: fg.Name,
+		Version:      fg.Version,
+		File:         file,
+		ChunkSize:    cp.ChunkSize,
+		Rows:         inserted(),
+		Chunks:       cp.Chunks,
+	}
+	if output.JSONMode {
+		output.PrintJSON(result)
+		return nil
+	}
+	rows := make([]output.Row, len(result.Chunks))
+	for i, c := range result.Chunks {
+		status := "inserted"
+		if c.Resumed {
+			status = "earlier run"
+		}
+		rows[i] = output.Row{c.Chunk + 1, c.Rows, status}
+	}
+	output.Table([]string{"CHUNK", "ROWS", "STATUS"}, rows)
+	output.Success("Inserted %d rows in %d chunk(s) into '%s' v%d", result.Rows, len(result.Chunks), fg.Name, fg.Version)
+	return nil
+}
+
+// chunkWriter passes script output through, except the chunk marker lines,
+// which it hands to onChunk.
+type chunkWriter struct {
+	w       io.Writer
+	buf     []byte
+	onChunk func(chunkResult)
+}
+
+func (cw *chunkWriter) Write(b []byte) (int, error) {
+	cw.buf = append(cw.buf, b...)
+	for {
+		i := bytes.IndexByte(cw.buf, '\n')
+		if i < 0 {
+			return len(b), nil
+		}
+		line := cw.buf[:i+1]
+		if rest, ok := bytes.CutPrefix(line, []byte(chunkMarker)); ok {
+			var c chunkResult
+			if err := json.Unmarshal(rest, &c); err == nil {
+				cw.onChunk(c)
+			}
+		} else if _, err := cw.w.Write(line); err != nil {
+			return len(b), err
+		}
+		cw.buf = cw.buf[i+1:]
+	}
+}
+
+// Flush writes out a last line that had no newline.
+func (cw *chunkWriter) Flush() {
+	if len(cw.buf) > 0 {
+		cw.w.Write(cw.buf)
+		cw.buf = nil
+	}
+}
+
+// buildChunkedInsertScript reads the file chunk by chunk (pandas chunksize,
+// or Parquet record batches) and inserts each one, skipping the chunks in
+// done. Offline materialization runs once at the end rather than per chunk.
+func buildChunkedInsertScript(fgName string, fgVersion int, filePath string, chunkSize int, done []int, coerce string, onlineOnly bool) string {
+	doneList := make([]string, len(done))
+	for i, c := range done {
+		doneList[i] = fmt.Sprint(c)
+	}
+	var casts strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(coerce), "\n") {
+		if line != "" {
+			casts.WriteString("    " + line + "\n")
+		}
+	}
+	materialize := `
+job = getattr(fg, "materialization_job", None)
+if job is not None:
+    print("Running the offline materialization job...")
+    job.run(await_termination=True)
+`
+	if onlineOnly {
+		materialize = ""
+	}
+
+	return fmt.Sprintf(`
+import hopsworks
+import pandas as pd
+import json
+import warnings
+warnings.filterwarnings("ignore")
+
+project = hopsworks.login()
+fs = project.get_feature_store()
+fg = fs.get_or_create_feature_group(name=%q, version=%d)
+
+file_path = %q
+chunk_size = %d
+done = set([%s])
+
+def chunks():
+    lower = file_path.lower()
+    if lower.endswith('.csv'):
+        yield from pd.read_csv(file_path, chunksize=chunk_size)
+    elif lower.endswith('.tsv'):
+        yield from pd.read_csv(file_path, sep='\t', chunksize=chunk_size)
+    elif lower.endswith(('.ndjson', '.jsonl')):
+        yield from pd.read_json(file_path, lines=True, chunksize=chunk_size)
+    else:
+        import pyarrow.parquet as pq
+        for batch in pq.ParquetFile(file_path).iter_batches(batch_size=chunk_size):
+            yield batch.to_pandas()
+
+for i, df in enumerate(chunks()):
+    if i in done:
+        continue
+%s    fg.insert(df, write_options={"start_offline_materialization": False}%s)
+    print(%q + json.dumps({"chunk": i, "rows": len(df)}), flush=True)
+%s`, fgName, fgVersion, filePath, chunkSize, strings.Join(doneList, ", "), casts.String(), storageSnippet(onlineOnly), chunkMarker, materialize)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -45,10 +46,18 @@ Examples:
 			output.Info("Searching '%s' v%d (k=%d)...", fg.Name, fg.Version, fgSearchK)
 		}
 
-		script := buildSearchScript(fg.Name, fg.Version, fgSearchVector, fgSearchK, fgSearchCol, output.JSONMode)
-		if err := runPython(script); err != nil {
+		script := buildSearchScript(fg.Name, fg.Version, fgSearchVector, fgSearchK, fgSearchCol, output.Structured())
+		if !output.Structured() {
+			if err := runPython(script); err != nil {
+				return fmt.Errorf("similarity search: %w", err)
+			}
+			return nil
+		}
+		var neighbors []json.RawMessage
+		if err := captureJSON(script, &neighbors); err != nil {
 			return fmt.Errorf("similarity search: %w", err)
 		}
+		output.PrintKind("Neighbor", neighbors)
 		return nil
 	},
 }
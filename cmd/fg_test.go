@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
+)
+
+func TestFeaturesFromFileCase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sample.csv")
+	if err := os.WriteFile(path, []byte("Order_ID,Amount,Event_Time\n1,2.5,2024-01-01 10:00:00\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := featuresFromFile(path, "AMOUNT:decimal(10,2)", []string{"Order_ID"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []client.Feature{
+		{Name: "order_id", Type: "bigint", Primary: true},
+		{Name: "amount", Type: "decimal(10,2)"},
+		{Name: "event_time", Type: "timestamp"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+
+	if _, err := featuresFromFile(path, "", []string{"missing"}); err == nil {
+		t.Error("want an error for a primary key that isn't a column")
+	}
+}
@@ -0,0 +1,314 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
+	"github.com/MagicLex/hopsworks-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fgUpdateAddFeatures     string
+	fgUpdateDesc            string
+	fgUpdateFeatureDescs    []string
+	fgUpdateEnableOnline    bool
+	fgUpdateDisableOnline   bool
+	fgUpdateEnableStats     bool
+	fgUpdateDisableStats    bool
+	fgUpdateHistograms      bool
+	fgUpdateCorrelations    bool
+	fgUpdateExactUniqueness bool
+	fgUpdateDryRun          bool
+)
+
+// fgUpdateFlags are the flags that change something; at least one is required.
+var fgUpdateFlags = []string{
+	"add-features", "description", "feature-description",
+	"enable-online", "disable-online",
+	"enable-statistics", "disable-statistics", "histograms", "correlations", "exact-uniqueness",
+}
+
+var fgUpdateCmd = &cobra.Command{
+	Use:   "update <name>",
+	Short: "Update a feature group's schema and metadata",
+	Long: `Update a feature group in place. Features can only be appended; removing or
+retyping a feature needs a new version.
+
+Examples:
+  hops fg update transactions --add-features "channel:string,risk_score:double"
+  hops fg update transactions --description "Card transactions, enriched"
+  hops fg update transactions --feature-description amount="Amount in EUR"
+  hops fg update transactions --enable-online
+  hops fg update transactions --disable-statistics
+  hops fg update transactions --histograms --correlations=false
+  hops fg update transactions --add-features "channel:string" --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !anyFlagChanged(cmd, fgUpdateFlags) {
+			return fmt.Errorf("nothing to update: pass at least one of --%s", strings.Join(fgUpdateFlags, ", --"))
+		}
+		if fgUpdateEnableOnline && fgUpdateDisableOnline {
+			return fmt.Errorf("--enable-online and --disable-online are mutually exclusive")
+		}
+		if fgUpdateEnableStats && fgUpdateDisableStats {
+			return fmt.Errorf("--enable-statistics and --disable-statistics are mutually exclusive")
+		}
+
+		c, err := mustClient()
+		if err != nil {
+			return err
+		}
+
+		fg, err := getFeatureGroupVersion(c, args[0], fgVersion)
+		if err != nil {
+			return err
+		}
+
+		next := *fg
+		next.Features = append([]client.Feature(nil), fg.Features...)
+		stats := statisticsConfigOf(fg)
+		next.StatisticsConfig = &stats
+
+		if cmd.Flags().Changed("description") {
+			next.Description = fgUpdateDesc
+		}
+		if fgUpdateAddFeatures != "" {
+			for _, f := range parseFeatureSpecs(fgUpdateAddFeatures, nil) {
+				if findFeature(next.Features, f.Name) >= 0 {
+					return fmt.Errorf("feature '%s' already exists in '%s' v%d", f.Name, fg.Name, fg.Version)
+				}
+				next.Features = append(next.Features, f)
+			}
+		}
+		for _, spec := range fgUpdateFeatureDescs {
+			name, desc, ok := strings.Cut(spec, "=")
+			if !ok {
+				return fmt.Errorf("invalid --feature-description %q (format: col=\"text\")", spec)
+			}
+			i := findFeature(next.Features, trimSpace(name))
+			if i < 0 {
+				return fmt.Errorf("feature '%s' not found in '%s' v%d", trimSpace(name), fg.Name, fg.Version)
+			}
+			next.Features[i].Description = strings.Trim(trimSpace(desc), `"'`)
+		}
+
+		if fgUpdateEnableOnline {
+			next.OnlineEnabled = true
+		}
+		if fgUpdateDisableOnline {
+			next.OnlineEnabled = false
+		}
+
+		if fgUpdateEnableStats {
+			stats.Enabled = true
+		}
+		if fgUpdateDisableStats {
+			stats.Enabled = false
+		}
+		if cmd.Flags().Changed("histograms") {
+			stats.Histograms = fgUpdateHistograms
+		}
+		if cmd.Flags().Changed("correlations") {
+			stats.Correlations = fgUpdateCorrelations
+		}
+		if cmd.Flags().Changed("exact-uniqueness") {
+			stats.ExactUniqueness = fgUpdateExactUniqueness
+		}
+
+		changes := diffFeatureGroups(fg, &next)
+		if fgUpdateDryRun || len(changes) == 0 {
+			if output.JSONMode {
+				output.PrintJSON(changes)
+				return nil
+			}
+			if len(changes) == 0 {
+				output.Info("'%s' v%d already matches; nothing to update", fg.Name, fg.Version)
+				return nil
+			}
+			output.Info("Changes to '%s' v%d (dry run, nothing applied):", fg.Name, fg.Version)
+			printSchemaChanges(changes)
+			return nil
+		}
+
+		// One PUT per kind of change; the backend applies only the part its flag names
+		var actions []string
+		if changedField(changes, "description", "features.") {
+			actions = append(actions, "updateMetadata")
+		}
+		if changedField(changes, "statistics.") {
+			actions = append(actions, "updateStatsConfig")
+		}
+		if changedField(changes, "online") {
+			if next.OnlineEnabled {
+				actions = append(actions, "enableOnline")
+			} else {
+				actions = append(actions, "disableOnline")
+			}
+		}
+		for _, action := range actions {
+			if _, err := c.UpdateFeatureGroup(&next, action); err != nil {
+				return fmt.Errorf("update feature group (%s): %w", action, err)
+			}
+		}
+
+		updated, err := c.GetFeatureGroup(fg.Name, fg.Version)
+		if err != nil {
+			return err
+		}
+		if output.JSONMode {
+			output.PrintJSON(updated)
+			return nil
+		}
+		printSchemaChanges(changes)
+		output.Success("Updated feature group '%s' v%d", fg.Name, fg.Version)
+		return nil
+	},
+}
+
+// schemaChange is one difference between two feature group definitions.
+type schemaChange struct {
+	Op    string      `json:"op"`    // added, removed, changed
+	Field string      `json:"field"` // e.g. description, online, features.amount, features.amount.type
+	Old   interface{} `json:"old,omitempty"`
+	New   interface{} `json:"new,omitempty"`
+	// Incompatible changes can't be applied in place and need a new version
+	Incompatible bool `json:"incompatible,omitempty"`
+}
+
+// diffFeatureGroups lists what changes from a to b: metadata, primary key,
+// event time, online, statistics config and features by name. Removing or
+// retyping a feature and changing the primary key or event time are marked
+// incompatible.
+func diffFeatureGroups(a, b *client.FeatureGroup) []schemaChange {
+	var changes []schemaChange
+	changed := func(field string, old, new interface{}) {
+		if old != new {
+			changes = append(changes, schemaChange{Op: "changed", Field: field, Old: old, New: new})
+		}
+	}
+
+	changed("description", a.Description, b.Description)
+	changed("primary_key", strings.Join(primaryKeys(a.Features), ","), strings.Join(primaryKeys(b.Features), ","))
+	changed("event_time", a.EventTime, b.EventTime)
+	changed("online", a.OnlineEnabled, b.OnlineEnabled)
+
+	sa, sb := statisticsConfigOf(a), statisticsConfigOf(b)
+	changed("statistics.enabled", sa.Enabled, sb.Enabled)
+	changed("statistics.histograms", sa.Histograms, sb.Histograms)
+	changed("statistics.correlations", sa.Correlations, sb.Correlations)
+	changed("statistics.exact_uniqueness", sa.ExactUniqueness, sb.ExactUniqueness)
+
+	for _, f := range a.Features {
+		i := findFeature(b.Features, f.Name)
+		if i < 0 {
+			changes = append(changes, schemaChange{Op: "removed", Field: "features." + f.Name, Old: f.Type})
+			continue
+		}
+		changed("features."+f.Name+".type", f.Type, b.Features[i].Type)
+		changed("features."+f.Name+".description", f.Description, b.Features[i].Description)
+	}
+	for _, f := range b.Features {
+		if findFeature(a.Features, f.Name) < 0 {
+			changes = append(changes, schemaChange{Op: "added", Field: "features." + f.Name, New: f.Type})
+		}
+	}
+	for i, ch := range changes {
+		changes[i].Incompatible = ch.Op == "removed" || ch.Field == "primary_key" || ch.Field == "event_time" ||
+			strings.HasSuffix(ch.Field, ".type")
+	}
+	return changes
+}
+
+// printSchemaChanges prints changes as a +/-/~ list.
+func printSchemaChanges(changes []schemaChange) {
+	for _, ch := range changes {
+		var line string
+		switch ch.Op {
+		case "added":
+			line = fmt.Sprintf("  + %s: %v", ch.Field, ch.New)
+		case "removed":
+			line = fmt.Sprintf("  - %s: %v", ch.Field, ch.Old)
+		default:
+			line = fmt.Sprintf("  ~ %s: %s → %s", ch.Field, changeValue(ch.Old), changeValue(ch.New))
+		}
+		if ch.Incompatible {
+			line += "  (incompatible)"
+		}
+		fmt.Println(line)
+	}
+}
+
+func changeValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprint(v)
+}
+
+// statisticsConfigOf returns fg's statistics config, or the backend default
+// (enabled, no histograms/correlations) when the API left it out.
+func statisticsConfigOf(fg *client.FeatureGroup) client.StatisticsConfig {
+	if fg.StatisticsConfig == nil {
+		return client.StatisticsConfig{Enabled: true}
+	}
+	return *fg.StatisticsConfig
+}
+
+func primaryKeys(features []client.Feature) []string {
+	var pks []string
+	for _, f := range features {
+		if f.Primary {
+			pks = append(pks, f.Name)
+		}
+	}
+	return pks
+}
+
+func findFeature(features []client.Feature, name string) int {
+	for i, f := range features {
+		if f.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func anyFlagChanged(cmd *cobra.Command, names []string) bool {
+	for _, name := range names {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// changedField reports whether any change's field starts with one of prefixes.
+func changedField(changes []schemaChange, prefixes ...string) bool {
+	for _, ch := range changes {
+		for _, p := range prefixes {
+			if strings.HasPrefix(ch.Field, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func init() {
+	fgUpdateCmd.Flags().IntVar(&fgVersion, "version", 0, "Feature group version (latest if omitted)")
+	fgUpdateCmd.Flags().StringVar(&fgUpdateAddFeatures, "add-features", "", "Append features: name:type,name:type,...")
+	fgUpdateCmd.Flags().StringVar(&fgUpdateDesc, "description", "", "New description")
+	fgUpdateCmd.Flags().StringArrayVar(&fgUpdateFeatureDescs, "feature-description", nil, `Feature description: col="text" (repeatable)`)
+	fgUpdateCmd.Flags().BoolVar(&fgUpdateEnableOnline, "enable-online", false, "Enable online storage")
+	fgUpdateCmd.Flags().BoolVar(&fgUpdateDisableOnline, "disable-online", false, "Disable online storage")
+	fgUpdateCmd.Flags().BoolVar(&fgUpdateEnableStats, "enable-statistics", false, "Compute statistics on insert")
+	fgUpdateCmd.Flags().BoolVar(&fgUpdateDisableStats, "disable-statistics", false, "Stop computing statistics on insert")
+	fgUpdateCmd.Flags().BoolVar(&fgUpdateHistograms, "histograms", false, "Compute histograms (--histograms=false to turn off)")
+	fgUpdateCmd.Flags().BoolVar(&fgUpdateCorrelations, "correlations", false, "Compute correlations (--correlations=false to turn off)")
+	fgUpdateCmd.Flags().BoolVar(&fgUpdateExactUniqueness, "exact-uniqueness", false, "Compute exact uniqueness (--exact-uniqueness=false to turn off)")
+	fgUpdateCmd.Flags().BoolVar(&fgUpdateDryRun, "dry-run", false, "Print the schema diff without applying it")
+
+	fgCmd.AddCommand(fgUpdateCmd)
+}
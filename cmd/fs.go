@@ -1,8 +1,7 @@
 package cmd
 
 import (
-	"strconv"
-
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
 	"github.com/MagicLex/hopsworks-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -12,6 +11,13 @@ var fsCmd = &cobra.Command{
 	Short: "Manage feature stores",
 }
 
+var fsListColumns = []output.Column[client.FeatureStore]{
+	{Name: "NAME", Value: func(s client.FeatureStore) interface{} { return s.FeaturestoreName }},
+	{Name: "ID", Value: func(s client.FeatureStore) interface{} { return s.FeaturestoreID }},
+	{Name: "ACTIVE", Value: func(s client.FeatureStore) interface{} { return activeMark(s.FeaturestoreID == cfg.FeatureStoreID) }},
+	{Name: "PROJECT", Wide: true, Value: func(s client.FeatureStore) interface{} { return s.ProjectID }},
+}
+
 var fsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List feature stores in the project",
@@ -21,35 +27,18 @@ var fsListCmd = &cobra.Command{
 			return err
 		}
 
-		stores, err := c.ListFeatureStores()
+		stores, err := c.ListFeatureStores(listPage())
 		if err != nil {
 			return err
 		}
+		stores = trimPage(stores)
 
-		if output.JSONMode {
-			output.PrintJSON(stores)
-			return nil
-		}
-
-		headers := []string{"NAME", "ID", "ACTIVE"}
-		var rows [][]string
-		for _, s := range stores {
-			active := ""
-			if s.FeaturestoreID == cfg.FeatureStoreID {
-				active = "*"
-			}
-			rows = append(rows, []string{
-				s.FeaturestoreName,
-				strconv.Itoa(s.FeaturestoreID),
-				active,
-			})
-		}
-		output.Table(headers, rows)
-		return nil
+		return printList(stores, fsListColumns)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(fsCmd)
+	addListFlags(fsListCmd)
 	fsCmd.AddCommand(fsListCmd)
 }
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/MagicLex/hopsworks-cli/pkg/client"
@@ -18,6 +17,16 @@ var fvCmd = &cobra.Command{
 	Short: "Manage feature views",
 }
 
+var fvListColumns = []output.Column[client.FeatureView]{
+	{Name: "NAME", Value: func(fv client.FeatureView) interface{} { return fv.Name }},
+	{Name: "VERSION", Value: func(fv client.FeatureView) interface{} { return fv.Version }},
+	{Name: "FEATURES", Value: func(fv client.FeatureView) interface{} { return len(fv.Features) }},
+	{Name: "DESCRIPTION", Flex: true, Value: func(fv client.FeatureView) interface{} { return fv.Description }},
+	{Name: "ID", Wide: true, Value: func(fv client.FeatureView) interface{} { return fv.ID }},
+	{Name: "CREATED", Wide: true, Value: func(fv client.FeatureView) interface{} { return apiTime(fv.Created) }},
+	{Name: "LABELS", Wide: true, Value: func(fv client.FeatureView) interface{} { return strings.Join(fv.Labels, ",") }},
+}
+
 var fvListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List feature views",
@@ -27,28 +36,13 @@ var fvListCmd = &cobra.Command{
 			return err
 		}
 
-		fvs, err := c.ListFeatureViews()
+		fvs, err := c.ListFeatureViews(listPage())
 		if err != nil {
 			return err
 		}
+		fvs = trimPage(fvs)
 
-		if output.JSONMode {
-			output.PrintJSON(fvs)
-			return nil
-		}
-
-		headers := []string{"NAME", "VERSION", "FEATURES", "DESCRIPTION"}
-		var rows [][]string
-		for _, fv := range fvs {
-			rows = append(rows, []string{
-				fv.Name,
-				strconv.Itoa(fv.Version),
-				strconv.Itoa(len(fv.Features)),
-				truncate(fv.Description, 40),
-			})
-		}
-		output.Table(headers, rows)
-		return nil
+		return printList(fvs, fvListColumns)
 	},
 }
 
@@ -94,9 +88,9 @@ var fvInfoCmd = &cobra.Command{
 			if len(qi.Features) > 0 {
 				output.Info("")
 				headers := []string{"FEATURE"}
-				var rows [][]string
+				var rows []output.Row
 				for _, f := range qi.Features {
-					rows = append(rows, []string{f})
+					rows = append(rows, output.Row{f})
 				}
 				output.Table(headers, rows)
 			}
@@ -105,9 +99,9 @@ var fvInfoCmd = &cobra.Command{
 		if len(fv.Features) > 0 {
 			output.Info("")
 			headers := []string{"FEATURE", "TYPE"}
-			var rows [][]string
+			var rows []output.Row
 			for _, f := range fv.Features {
-				rows = append(rows, []string{f.Name, f.Type})
+				rows = append(rows, output.Row{f.Name, f.Type})
 			}
 			output.Table(headers, rows)
 		}
@@ -246,23 +240,60 @@ Join spec: "<fg>[:<version>] <INNER|LEFT|RIGHT|FULL> <on>[=<right_on>] [prefix]"
 var fvDeleteCmd = &cobra.Command{
 	Use:   "delete <name>",
 	Short: "Delete a feature view",
-	Args:  cobra.ExactArgs(1),
+	Long: `Delete a feature view version, or all of them when --version is omitted
+(or with --all-versions).
+
+Before deleting, lists its training datasets, the models trained on it and the
+deployments serving them. If there are any, asks for confirmation, or needs
+--force when not run from a terminal.
+
+Examples:
+  hops fv delete my_view --version 1 --dry-run
+  hops fv delete my_view --version 1
+  hops fv delete my_view --force`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if fvVersion > 0 && deleteAllVersions {
+			return fmt.Errorf("--version and --all-versions are mutually exclusive")
+		}
+		// Without --version, all versions go, as before --all-versions existed
+		allVersions := fvVersion == 0
+
 		c, err := mustClient()
 		if err != nil {
 			return err
 		}
 
-		if err := c.DeleteFeatureView(args[0], fvVersion); err != nil {
-			return err
+		var fvs []client.FeatureView
+		if allVersions {
+			if fvs, err = c.ListFeatureViewVersions(args[0]); err != nil {
+				return err
+			}
+			if len(fvs) == 0 {
+				return fmt.Errorf("feature view '%s' not found", args[0])
+			}
+		} else {
+			fv, err := c.GetFeatureView(args[0], fvVersion)
+			if err != nil {
+				return err
+			}
+			fvs = []client.FeatureView{*fv}
 		}
 
-		if fvVersion > 0 {
-			output.Success("Deleted feature view '%s' v%d", args[0], fvVersion)
-		} else {
-			output.Success("Deleted all versions of feature view '%s'", args[0])
+		scan := newDependencyScan(c)
+		for _, fv := range fvs {
+			scan.target(artifactRef{Kind: "featureView", Name: fv.Name, Version: fv.Version})
 		}
-		return nil
+		for _, fv := range fvs {
+			scan.featureView(fv.Name, fv.Version)
+		}
+		if ok, err := confirmDelete(scan); !ok {
+			return err
+		}
+
+		return deleteTargets(scan, func(i int) error {
+			return c.DeleteFeatureView(fvs[i].Name, fvs[i].Version)
+		})
 	},
 }
 
@@ -279,7 +310,9 @@ func init() {
 	fvCreateCmd.Flags().StringArrayVar(&fvCreateJoins, "join", nil, `Join spec: "<fg>[:<ver>] <INNER|LEFT|RIGHT|FULL> <on>[=<right_on>] [prefix]"`)
 	fvCreateCmd.Flags().StringArrayVar(&fvCreateTransforms, "transform", nil, `Transform spec: "fn_name:column"`)
 	fvDeleteCmd.Flags().IntVar(&fvVersion, "version", 0, "Version to delete (all if omitted)")
+	addDeleteFlags(fvDeleteCmd)
 
+	addListFlags(fvListCmd)
 	fvCmd.AddCommand(fvListCmd)
 	fvCmd.AddCommand(fvInfoCmd)
 	fvCmd.AddCommand(fvCreateCmd)
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"regexp"
 	"strings"
 
+	"github.com/MagicLex/hopsworks-cli/pkg/config"
 	"github.com/MagicLex/hopsworks-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -32,27 +37,225 @@ fv = fs.get_feature_view(name=%q, version=%d)
 
 // runPython executes a Python script with mTLS env vars for HDFS access.
 func runPython(script string) error {
-	pyCmd := exec.Command("python3", "-c", script)
-	pyCmd.Stdout = os.Stdout
-	pyCmd.Stderr = os.Stderr
-	pyCmd.Stdin = os.Stdin
-	pyCmd.Env = append(os.Environ(),
-		"PEMS_DIR="+os.ExpandEnv("${HOME}/.hopsfs_pems"),
-		"LIBHDFS_DEFAULT_USER="+os.Getenv("HADOOP_USER_NAME"),
-	)
-	return pyCmd.Run()
+	return withTokenRetry(func(stderr io.Writer) error {
+		pyCmd := pythonCmd(script)
+		pyCmd.Stdout = os.Stdout
+		pyCmd.Stderr = stderr
+		pyCmd.Stdin = os.Stdin
+		return pyCmd.Run()
+	})
+}
+
+// runPythonProgress is runPython with a progress indicator on stderr for
+// scripts that run for minutes (materialization, uploads).
+func runPythonProgress(title, script string) error {
+	p := output.StartProgress(title)
+	err := withTokenRetry(func(stderr io.Writer) error {
+		pyCmd := pythonCmd(script)
+		pyCmd.Stdout = p.Wrap(scriptStdout())
+		pyCmd.Stderr = p.Wrap(stderr)
+		pyCmd.Stdin = os.Stdin
+		return pyCmd.Run()
+	})
+	p.Done(err)
+	return err
 }
 
 // runPythonCapture executes a Python script and captures stdout (stderr goes to terminal).
 func runPythonCapture(script string) ([]byte, error) {
-	pyCmd := exec.Command("python3", "-c", script)
-	pyCmd.Stderr = os.Stderr
-	pyCmd.Stdin = os.Stdin
-	pyCmd.Env = append(os.Environ(),
+	var out []byte
+	err := withTokenRetry(func(stderr io.Writer) error {
+		pyCmd := pythonCmd(script)
+		pyCmd.Stderr = stderr
+		pyCmd.Stdin = os.Stdin
+		var err error
+		out, err = pyCmd.Output()
+		return err
+	})
+	return out, err
+}
+
+// scriptStdout is where progress printed by a Python script goes: stderr when
+// the CLI itself prints a structured result, so stdout stays parseable.
+func scriptStdout() io.Writer {
+	if output.Structured() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// captureJSON runs a script whose last line of stdout is a JSON document and
+// decodes it into v. Anything printed before it (SDK banners) is dropped.
+func captureJSON(script string, v interface{}) error {
+	out, err := runPythonCapture(script)
+	if err != nil {
+		return err
+	}
+	out = bytes.TrimSpace(out)
+	if i := bytes.LastIndexByte(out, '\n'); i >= 0 {
+		out = out[i+1:]
+	}
+	if err := json.Unmarshal(out, v); err != nil {
+		return fmt.Errorf("decode script output: %w", err)
+	}
+	return nil
+}
+
+// dataFile is the structured result of reading data into --file.
+type dataFile struct {
+	Path string `json:"path"`
+	Rows int    `json:"rows"`
+}
+
+// runDataFrameScript runs a script built with printDataFrame. In structured
+// formats the rows (or the saved file) are printed by the output package.
+func runDataFrameScript(script, outputPath string) error {
+	if !output.Structured() {
+		return runPython(script)
+	}
+	if outputPath != "" {
+		var f dataFile
+		if err := captureJSON(script, &f); err != nil {
+			return err
+		}
+		output.PrintJSON(&f)
+		return nil
+	}
+	var rows []json.RawMessage
+	if err := captureJSON(script, &rows); err != nil {
+		return err
+	}
+	output.PrintKind("Row", rows)
+	return nil
+}
+
+func pythonEnv() []string {
+	return append(os.Environ(),
 		"PEMS_DIR="+os.ExpandEnv("${HOME}/.hopsfs_pems"),
 		"LIBHDFS_DEFAULT_USER="+os.Getenv("HADOOP_USER_NAME"),
 	)
-	return pyCmd.Output()
+}
+
+// loginMarker is the stderr line a script prints once hopsworks.login()
+// returned; withTokenRetry only reruns scripts that never got that far.
+const loginMarker = "hops-logged-in"
+
+var loginLine = regexp.MustCompile(`(?m)^([ \t]*)project = hopsworks\.login\(\)\n`)
+
+// pythonCmd returns the python3 command for script, with the mTLS env vars.
+// In internal mode the script also prints loginMarker after logging in.
+func pythonCmd(script string) *exec.Cmd {
+	if cfg != nil && cfg.Internal {
+		script = loginLine.ReplaceAllString(script, "${0}${1}print(\""+loginMarker+"\", file=__import__(\"sys\").stderr, flush=True)\n")
+	}
+	pyCmd := exec.Command("python3", "-c", script)
+	pyCmd.Env = pythonEnv()
+	return pyCmd
+}
+
+// withTokenRetry runs a Python subprocess and, in internal mode, recovers from
+// JWT expiry: it fails fast if token.jwt is already stale, and reruns once
+// when the script died on a 401 during login and the token file has rotated
+// since. A 401 after login is not retried: the script may already have
+// written something, and running it again could duplicate it.
+func withTokenRetry(run func(stderr io.Writer) error) error {
+	if cfg == nil || !cfg.Internal {
+		return run(os.Stderr)
+	}
+	if config.TokenFileStale() {
+		return fmt.Errorf("%w (%s)", config.ErrTokenExpired, config.TokenPath())
+	}
+
+	before := config.TokenModTime()
+	sniff := &authFailureWriter{w: os.Stderr, lineStart: true}
+	err := run(sniff)
+	sniff.Flush()
+	if err == nil || !sniff.seen {
+		return err
+	}
+	if sniff.loggedIn || config.TokenModTime().Equal(before) {
+		if config.TokenFileStale() {
+			return fmt.Errorf("%w (%s)", config.ErrTokenExpired, config.TokenPath())
+		}
+		if sniff.loggedIn {
+			return fmt.Errorf("%w (token rejected after login; not retried since the script may have made changes)", err)
+		}
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "JWT token rotated, retrying once")
+	return run(&authFailureWriter{w: os.Stderr, lineStart: true})
+}
+
+// authFailureWriter passes subprocess stderr through, watching it for signs
+// of a rejected token and taking out the loginMarker line.
+type authFailureWriter struct {
+	w         io.Writer
+	tail      string
+	seen      bool
+	loggedIn  bool
+	lineStart bool   // the next byte starts a line
+	held      []byte // start of a line that may be the marker
+}
+
+// Matched case-insensitively against hopsworks/requests error output
+var authFailureMarkers = []string{"http code: 401", "401 client error", "unauthorized", "token expired", "tokenexpired", "token is expired"}
+
+func (w *authFailureWriter) Write(p []byte) (int, error) {
+	buf := append(w.held, p...)
+	w.held = nil
+	marker := loginMarker + "\n"
+	var out []byte
+	for len(buf) > 0 {
+		if w.lineStart {
+			if bytes.HasPrefix(buf, []byte(marker)) {
+				w.loggedIn = true
+				buf = buf[len(marker):]
+				continue
+			}
+			if len(buf) < len(marker) && strings.HasPrefix(marker, string(buf)) {
+				w.held = append([]byte(nil), buf...)
+				break
+			}
+		}
+		i := bytes.IndexByte(buf, '\n')
+		if i < 0 {
+			out, buf, w.lineStart = append(out, buf...), nil, false
+		} else {
+			out, buf, w.lineStart = append(out, buf[:i+1]...), buf[i+1:], true
+		}
+	}
+	w.sniff(out)
+	if _, err := w.w.Write(out); err != nil {
+		return len(p), err
+	}
+	return len(p), nil
+}
+
+func (w *authFailureWriter) sniff(p []byte) {
+	if w.seen {
+		return
+	}
+	// Keep a short tail so markers split across writes still match
+	buf := w.tail + strings.ToLower(string(p))
+	for _, m := range authFailureMarkers {
+		if strings.Contains(buf, m) {
+			w.seen = true
+			return
+		}
+	}
+	if len(buf) > 32 {
+		buf = buf[len(buf)-32:]
+	}
+	w.tail = buf
+}
+
+// Flush writes out a held line start that turned out not to be the marker.
+func (w *authFailureWriter) Flush() {
+	if len(w.held) > 0 {
+		w.sniff(w.held)
+		w.w.Write(w.held)
+		w.held = nil
+	}
 }
 
 // pythonLiteral converts a string to a Python literal (number or quoted string).
@@ -103,10 +306,18 @@ Examples:
 			output.Info("Looking up feature vectors from '%s' v%d...", args[0], ver)
 		}
 
-		script := buildFVGetScript(args[0], ver, fvGetEntries, output.JSONMode)
-		if err := runPython(script); err != nil {
+		script := buildFVGetScript(args[0], ver, fvGetEntries, output.Structured())
+		if !output.Structured() {
+			if err := runPython(script); err != nil {
+				return fmt.Errorf("get feature vector: %w", err)
+			}
+			return nil
+		}
+		var vectors []json.RawMessage
+		if err := captureJSON(script, &vectors); err != nil {
 			return fmt.Errorf("get feature vector: %w", err)
 		}
+		output.PrintKind("FeatureVector", vectors)
 		return nil
 	},
 }
@@ -134,7 +345,7 @@ func buildFVGetScript(fvName string, version int, entries []string, jsonMode boo
 		sb.WriteString(fmt.Sprintf("result = fv.get_feature_vector(entry=%s)\n", pyEntries[0]))
 		sb.WriteString("row = dict(zip(feature_names, result))\n")
 		if jsonMode {
-			sb.WriteString("print(json.dumps(row, default=str))\n")
+			sb.WriteString("print(json.dumps([row], default=str))\n")
 		} else {
 			sb.WriteString("for k, v in row.items():\n")
 			sb.WriteString("    print(f'{k}: {v}')\n")
@@ -164,9 +375,10 @@ var fvReadCmd = &cobra.Command{
 Examples:
   hops fv read my_view
   hops fv read my_view --n 100
-  hops fv read my_view --output data.parquet
-  hops fv read my_view --output data.csv`,
-	Args: cobra.ExactArgs(1),
+  hops fv read my_view --file data.parquet
+  hops fv read my_view -o csv > data.csv`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{legacyOutputFlag: "file"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ver := fvVersion
 		if ver == 0 {
@@ -181,15 +393,15 @@ Examples:
 			}
 		}
 
-		script := buildFVReadScript(args[0], ver, fvReadOutput, fvReadN, output.JSONMode)
-		if err := runPython(script); err != nil {
+		script := buildFVReadScript(args[0], ver, fvReadOutput, fvReadN, output.Structured())
+		if err := runDataFrameScript(script, fvReadOutput); err != nil {
 			return fmt.Errorf("read batch: %w", err)
 		}
 		return nil
 	},
 }
 
-func buildFVReadScript(fvName string, version int, outputPath string, n int, jsonMode bool) string {
+func buildFVReadScript(fvName string, version int, outputPath string, n int, structured bool) string {
 	var sb strings.Builder
 	sb.WriteString(buildFVPreamble(fvName, version))
 
@@ -211,20 +423,30 @@ func buildFVReadScript(fvName string, version int, outputPath string, n int, jso
 			sb.WriteString(fmt.Sprintf("df.to_parquet(%q, index=False)\n", outputPath))
 		}
 		sb.WriteString(fmt.Sprintf("print('Saved to %s', file=sys.stderr)\n", outputPath))
-	} else if jsonMode {
-		sb.WriteString("print(df.to_json(orient='records', indent=2))\n")
+		if structured {
+			sb.WriteString(fmt.Sprintf("print(json.dumps({'path': %q, 'rows': len(df)}))\n", outputPath))
+		}
 	} else {
-		sb.WriteString("print(df.to_string(index=False))\n")
+		sb.WriteString(printDataFrame(structured))
 	}
 
 	return sb.String()
 }
 
+// printDataFrame returns the Python line that prints df to stdout: a table, or
+// JSON records for the output package to render in the -o format.
+func printDataFrame(structured bool) string {
+	if structured {
+		return "print(df.to_json(orient='records', date_format='iso'))\n"
+	}
+	return "print(df.to_string(index=False))\n"
+}
+
 func init() {
 	fvGetCmd.Flags().StringArrayVar(&fvGetEntries, "entry", nil, `Primary key entry: "key=value" (repeatable)`)
 	fvGetCmd.Flags().IntVar(&fvVersion, "version", 0, "Feature view version (default: 1)")
 
-	fvReadCmd.Flags().StringVar(&fvReadOutput, "output", "", "Save to file (.parquet, .csv, .json)")
+	fvReadCmd.Flags().StringVar(&fvReadOutput, "file", "", "Save to file (.parquet, .csv, .json)")
 	fvReadCmd.Flags().IntVar(&fvReadN, "n", 0, "Limit rows")
 	fvReadCmd.Flags().IntVar(&fvVersion, "version", 0, "Feature view version (default: 1)")
 
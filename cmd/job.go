@@ -3,7 +3,6 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
@@ -60,6 +59,24 @@ var jobCmd = &cobra.Command{
 
 // --- list ---
 
+var jobListColumns = []output.Column[client.Job]{
+	{Name: "NAME", Value: func(j client.Job) interface{} { return j.Name }},
+	{Name: "TYPE", Value: func(j client.Job) interface{} {
+		if j.JobType == "" {
+			return j.ConfigType()
+		}
+		return j.JobType
+	}},
+	{Name: "CREATOR", Value: func(j client.Job) interface{} {
+		if j.Creator == nil {
+			return nil
+		}
+		return j.Creator.Email
+	}},
+	{Name: "ID", Wide: true, Value: func(j client.Job) interface{} { return j.ID }},
+	{Name: "CREATED", Wide: true, Value: func(j client.Job) interface{} { return apiTime(j.CreationTime) }},
+}
+
 var jobListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List jobs in the project",
@@ -69,31 +86,13 @@ var jobListCmd = &cobra.Command{
 			return err
 		}
 
-		jobs, err := c.ListJobs()
+		jobs, err := c.ListJobs(listPage())
 		if err != nil {
 			return err
 		}
+		jobs = trimPage(jobs)
 
-		if output.JSONMode {
-			output.PrintJSON(jobs)
-			return nil
-		}
-
-		headers := []string{"NAME", "TYPE", "CREATOR"}
-		var rows [][]string
-		for _, j := range jobs {
-			jobType := j.JobType
-			if jobType == "" {
-				jobType = j.ConfigType()
-			}
-			creator := ""
-			if j.Creator != nil {
-				creator = j.Creator.Email
-			}
-			rows = append(rows, []string{j.Name, jobType, creator})
-		}
-		output.Table(headers, rows)
-		return nil
+		return printList(jobs, jobListColumns)
 	},
 }
 
@@ -317,7 +316,7 @@ var jobRunCmd = &cobra.Command{
 		}
 
 		// Poll until terminal
-		return pollExecution(c, jobName, jobStatusPoll)
+		return pollExecution(c, jobName, exec, jobStatusPoll)
 	},
 }
 
@@ -424,21 +423,21 @@ var jobHistoryCmd = &cobra.Command{
 			return err
 		}
 
-		execs, err := c.GetExecutions(jobName, jobHistoryLimit)
+		execs, err := c.GetExecutions(jobName, client.Page{Limit: jobHistoryLimit})
 		if err != nil {
 			return err
 		}
 
-		if output.JSONMode {
+		if output.Structured() {
 			output.PrintJSON(execs)
 			return nil
 		}
 
 		headers := []string{"ID", "STATE", "STATUS", "DURATION", "SUBMITTED"}
-		var rows [][]string
+		var rows []output.Row
 		for _, e := range execs {
-			rows = append(rows, []string{
-				strconv.Itoa(e.ID),
+			rows = append(rows, output.Row{
+				e.ID,
 				e.State,
 				e.FinalStatus,
 				formatDuration(e.Duration),
@@ -452,6 +451,11 @@ var jobHistoryCmd = &cobra.Command{
 
 // --- delete ---
 
+// jobDeleteResult is the JSON form of 'hops job delete'.
+type jobDeleteResult struct {
+	Deleted string `json:"deleted"`
+}
+
 var jobDeleteCmd = &cobra.Command{
 	Use:   "delete <name>",
 	Short: "Delete a job",
@@ -469,7 +473,7 @@ var jobDeleteCmd = &cobra.Command{
 		}
 
 		if output.JSONMode {
-			output.PrintJSON(map[string]string{"deleted": jobName})
+			output.PrintJSON(&jobDeleteResult{Deleted: jobName})
 			return nil
 		}
 		output.Success("Deleted job '%s'", jobName)
@@ -599,6 +603,11 @@ var jobScheduleInfoCmd = &cobra.Command{
 	},
 }
 
+// jobUnscheduleResult is the JSON form of 'hops job unschedule'.
+type jobUnscheduleResult struct {
+	Unscheduled string `json:"unscheduled"`
+}
+
 var jobUnscheduleCmd = &cobra.Command{
 	Use:   "unschedule <name>",
 	Short: "Remove a job's schedule",
@@ -614,7 +623,7 @@ var jobUnscheduleCmd = &cobra.Command{
 		}
 
 		if output.JSONMode {
-			output.PrintJSON(map[string]string{"unscheduled": args[0]})
+			output.PrintJSON(&jobUnscheduleResult{Unscheduled: args[0]})
 			return nil
 		}
 		output.Success("Removed schedule for '%s'", args[0])
@@ -642,49 +651,38 @@ Examples:
 			return err
 		}
 
-		for {
-			exec, err := c.GetLatestExecution(jobName)
-			if err != nil {
-				return err
-			}
-			if exec == nil {
-				output.Info("No executions found for '%s'", jobName)
-				return nil
-			}
+		exec, err := c.GetLatestExecution(jobName)
+		if err != nil {
+			return err
+		}
+		if exec == nil {
+			output.Info("No executions found for '%s'", jobName)
+			return nil
+		}
 
-			if output.JSONMode {
-				output.PrintJSON(exec)
-				if !jobStatusWait || client.IsExecutionTerminal(exec.State) {
-					return nil
-				}
-				time.Sleep(time.Duration(jobStatusPoll) * time.Second)
-				continue
+		if jobStatusWait && !client.IsExecutionTerminal(exec.State) {
+			if exec, err = waitExecution(c, jobName, exec, jobStatusPoll); err != nil {
+				return err
 			}
+		}
 
-			dur := formatDuration(exec.Duration)
-			fmt.Printf("  Job:      %s\n", jobName)
-			fmt.Printf("  Exec:     #%d\n", exec.ID)
-			fmt.Printf("  State:    %s\n", exec.State)
-			fmt.Printf("  Status:   %s\n", exec.FinalStatus)
-			fmt.Printf("  Duration: %s\n", dur)
-			fmt.Printf("  Started:  %s\n", exec.SubmissionTime)
-
-			if client.IsExecutionTerminal(exec.State) {
-				if exec.FinalStatus == "SUCCEEDED" {
-					output.Success("Job finished successfully in %s", dur)
-				} else {
-					output.Error("Job %s (%s)", exec.State, exec.FinalStatus)
-				}
-				return nil
-			}
+		if output.JSONMode {
+			output.PrintJSON(exec)
+			return nil
+		}
 
-			if !jobStatusWait {
-				return nil
-			}
+		dur := formatDuration(exec.Duration)
+		fmt.Printf("  Job:      %s\n", jobName)
+		fmt.Printf("  Exec:     #%d\n", exec.ID)
+		fmt.Printf("  State:    %s\n", exec.State)
+		fmt.Printf("  Status:   %s\n", exec.FinalStatus)
+		fmt.Printf("  Duration: %s\n", dur)
+		fmt.Printf("  Started:  %s\n", exec.SubmissionTime)
 
-			fmt.Printf("  Polling every %ds...\n\n", jobStatusPoll)
-			time.Sleep(time.Duration(jobStatusPoll) * time.Second)
+		if client.IsExecutionTerminal(exec.State) {
+			reportExecution(exec)
 		}
+		return nil
 	},
 }
 
@@ -701,35 +699,70 @@ func formatDuration(ms int64) string {
 	return fmt.Sprintf("%dm%ds", int(d.Minutes()), int(d.Seconds())%60)
 }
 
-func pollExecution(c *client.Client, jobName string, pollSec int) error {
+// pollExecution waits for the latest execution of jobName and reports how it
+// ended. In JSON mode only the final execution is printed.
+func pollExecution(c *client.Client, jobName string, exec *client.Execution, pollSec int) error {
+	exec, err := waitExecution(c, jobName, exec, pollSec)
+	if err != nil {
+		return err
+	}
+	if output.JSONMode {
+		output.PrintJSON(exec)
+		return nil
+	}
+	fmt.Printf("  #%d  %s  %s  %s\n", exec.ID, exec.State, exec.FinalStatus, formatDuration(exec.Duration))
+	reportExecution(exec)
+	return nil
+}
+
+// waitExecution polls the latest execution of jobName, starting from exec,
+// until it reaches a terminal state, showing progress on stderr.
+func waitExecution(c *client.Client, jobName string, exec *client.Execution, pollSec int) (*client.Execution, error) {
 	if pollSec <= 0 {
 		pollSec = 10
 	}
+	p := output.StartProgress(fmt.Sprintf("%s #%d", jobName, exec.ID))
 	for {
-		exec, err := c.GetLatestExecution(jobName)
-		if err != nil {
-			return err
+		p.Update(exec.State, exec.Progress)
+		if client.IsExecutionTerminal(exec.State) {
+			p.Done(nil)
+			return exec, nil
 		}
-		if exec == nil {
-			return fmt.Errorf("execution disappeared")
+		if err := pollSleep(pollSec); err != nil {
+			p.Done(err)
+			return nil, err
 		}
 
-		if output.JSONMode {
-			output.PrintJSON(exec)
-		} else {
-			fmt.Printf("  #%d  %s  %s  %s\n", exec.ID, exec.State, exec.FinalStatus, formatDuration(exec.Duration))
+		next, err := c.GetLatestExecution(jobName)
+		if err == nil && next == nil {
+			err = fmt.Errorf("execution disappeared")
 		}
-
-		if client.IsExecutionTerminal(exec.State) {
-			if exec.FinalStatus == "SUCCEEDED" {
-				output.Success("Job finished successfully")
-			} else {
-				output.Error("Job %s (%s)", exec.State, exec.FinalStatus)
-			}
-			return nil
+		if err != nil {
+			p.Done(err)
+			return nil, err
 		}
+		exec = next
+	}
+}
 
-		time.Sleep(time.Duration(pollSec) * time.Second)
+// reportExecution prints how a finished execution ended.
+func reportExecution(exec *client.Execution) {
+	if exec.FinalStatus == "SUCCEEDED" {
+		output.Success("Job finished successfully in %s", formatDuration(exec.Duration))
+	} else {
+		output.Error("Job %s (%s)", exec.State, exec.FinalStatus)
+	}
+}
+
+// pollSleep waits between polls, returning early if the command is interrupted.
+func pollSleep(sec int) error {
+	t := time.NewTimer(time.Duration(sec) * time.Second)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-cmdCtx.Done():
+		return fmt.Errorf("interrupted: %w", cmdCtx.Err())
 	}
 }
 
@@ -737,6 +770,7 @@ func pollExecution(c *client.Client, jobName string, pollSec int) error {
 
 func init() {
 	rootCmd.AddCommand(jobCmd)
+	addListFlags(jobListCmd)
 	jobCmd.AddCommand(jobListCmd)
 	jobCmd.AddCommand(jobInfoCmd)
 
@@ -0,0 +1,394 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
+	"github.com/MagicLex/hopsworks-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lineageVersion   int
+	lineageTDVersion int
+	lineageDepth     int
+	lineageDirection string
+	lineageFormat    string
+)
+
+// lineageNode is one artifact in a lineage graph, with what it was built
+// from (upstream) and what was built from it (downstream).
+type lineageNode struct {
+	Kind       string         `json:"kind"` // featureGroup, featureView, trainingDataset, model, deployment
+	Name       string         `json:"name"`
+	Version    int            `json:"version,omitempty"`
+	Status     string         `json:"status,omitempty"` // deleted, inaccessible or faulty
+	Upstream   []*lineageNode `json:"upstream,omitempty"`
+	Downstream []*lineageNode `json:"downstream,omitempty"`
+}
+
+func (n *lineageNode) String() string {
+	s := artifactRef{Kind: n.Kind, Name: n.Name, Version: n.Version}.String()
+	if n.Status != "" {
+		s += " (" + n.Status + ")"
+	}
+	return s
+}
+
+func (n *lineageNode) key() string {
+	return fmt.Sprintf("%s/%s/%d", n.Kind, n.Name, n.Version)
+}
+
+var lineageCmd = &cobra.Command{
+	Use:   "lineage <fg|fv|td|model> <name>",
+	Short: "Show what an artifact was built from and what depends on it",
+	Long: `Walk the provenance graph around a feature group, feature view, training
+dataset or model: upstream to the sources it was built from, downstream to
+what was built from it (FG → FV → TD → model → deployment).
+
+A training dataset is named by its feature view: pass the feature view name,
+--version for its version and --td-version for the training dataset.
+
+--format renders the graph as an ASCII tree, Graphviz DOT or a Mermaid
+flowchart; --json returns the tree.
+
+Examples:
+  hops lineage fg transactions --version 1
+  hops lineage fv fraud_view --version 1 --direction down
+  hops lineage td fraud_view --version 1 --td-version 2
+  hops lineage model fraud_model --direction up --depth 5
+  hops lineage fg transactions --format dot | dot -Tsvg > lineage.svg
+  hops lineage fg transactions --format mermaid`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if lineageDepth <= 0 {
+			return fmt.Errorf("--depth must be positive")
+		}
+		var up, down int
+		switch lineageDirection {
+		case "up":
+			up = lineageDepth
+		case "down":
+			down = lineageDepth
+		case "both":
+			up, down = lineageDepth, lineageDepth
+		default:
+			return fmt.Errorf("invalid --direction %q: want up, down or both", lineageDirection)
+		}
+		switch lineageFormat {
+		case "tree", "dot", "mermaid":
+		default:
+			return fmt.Errorf("invalid --format %q: want tree, dot or mermaid", lineageFormat)
+		}
+
+		c, err := mustClient()
+		if err != nil {
+			return err
+		}
+
+		var root *lineageNode
+		var prov *client.ProvenanceLink
+		switch args[0] {
+		case "fg":
+			fg, err := getFeatureGroupVersion(c, args[1], lineageVersion)
+			if err != nil {
+				return err
+			}
+			root = &lineageNode{Kind: "featureGroup", Name: fg.Name, Version: fg.Version}
+			prov, err = c.GetFeatureGroupProvenance(fg.ID, up, down)
+			if err != nil {
+				return fmt.Errorf("provenance: %w", err)
+			}
+		case "fv", "td":
+			tdVersion := 0
+			if args[0] == "td" {
+				if lineageVersion == 0 || lineageTDVersion == 0 {
+					return fmt.Errorf("td lineage needs --version (feature view) and --td-version")
+				}
+				tdVersion = lineageTDVersion
+			}
+			fv, err := c.GetFeatureView(args[1], lineageVersion)
+			if err != nil {
+				return err
+			}
+			root = &lineageNode{Kind: "featureView", Name: fv.Name, Version: fv.Version}
+			if tdVersion > 0 {
+				root = &lineageNode{Kind: "trainingDataset", Name: fmt.Sprintf("%s_%d", fv.Name, fv.Version), Version: tdVersion}
+			}
+			prov, err = c.GetFeatureViewProvenance(fv.Name, fv.Version, tdVersion, up, down)
+			if err != nil {
+				return fmt.Errorf("provenance: %w", err)
+			}
+		case "model":
+			model, err := c.GetModel(args[1], lineageVersion)
+			if err != nil {
+				return err
+			}
+			root = &lineageNode{Kind: "model", Name: model.Name, Version: model.Version}
+			prov, err = c.GetModelProvenance(model.Name, model.Version, up, down)
+			if err != nil {
+				return fmt.Errorf("provenance: %w", err)
+			}
+		default:
+			return fmt.Errorf("invalid artifact %q: want fg, fv, td or model", args[0])
+		}
+
+		root.Upstream = lineageNodes(prov.Upstream, true)
+		root.Downstream = lineageNodes(prov.Downstream, false)
+		if down > 0 {
+			if err := addDeployments(c, root, down); err != nil {
+				return err
+			}
+		}
+
+		if output.JSONMode {
+			output.PrintJSON(root)
+			return nil
+		}
+		switch lineageFormat {
+		case "dot":
+			fmt.Print(lineageDOT(root))
+		case "mermaid":
+			fmt.Print(lineageMermaid(root))
+		default:
+			fmt.Print(lineageTree(root))
+			if len(root.Upstream) == 0 && len(root.Downstream) == 0 {
+				output.Info("No lineage recorded")
+			}
+		}
+		return nil
+	},
+}
+
+// lineageNodes converts provenance links, following them in one direction.
+func lineageNodes(links []client.ProvenanceLink, upstream bool) []*lineageNode {
+	var nodes []*lineageNode
+	for _, link := range links {
+		n := &lineageNode{
+			Kind:    lineageKind(link.Node.ArtifactType),
+			Name:    link.Node.Artifact.Name,
+			Version: link.Node.Artifact.Version,
+		}
+		switch {
+		case link.Node.Deleted:
+			n.Status = "deleted"
+		case link.Node.ExceptionCause != "":
+			n.Status = "faulty"
+		case !link.Node.Accessible:
+			n.Status = "inaccessible"
+		}
+		if upstream {
+			n.Upstream = lineageNodes(link.Upstream, true)
+		} else {
+			n.Downstream = lineageNodes(link.Downstream, false)
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// lineageKind maps a provenance artifact type to an artifactRef kind.
+// Derived and external feature groups come in as their own types.
+func lineageKind(artifactType string) string {
+	switch {
+	case strings.Contains(artifactType, "FEATURE_GROUP"):
+		return "featureGroup"
+	case artifactType == client.ArtifactFeatureView:
+		return "featureView"
+	case artifactType == client.ArtifactTrainingDataset:
+		return "trainingDataset"
+	case artifactType == client.ArtifactModel:
+		return "model"
+	}
+	return strings.ToLower(artifactType)
+}
+
+// addDeployments hangs the deployments serving each model within depth
+// levels downstream of root; provenance stops at models.
+func addDeployments(c *client.Client, root *lineageNode, depth int) error {
+	var deployments []client.Deployment
+	var fetched bool
+	var walk func(n *lineageNode, level int) error
+	walk = func(n *lineageNode, level int) error {
+		if n.Kind == "model" && level < depth {
+			if !fetched {
+				var err error
+				if deployments, err = c.ListDeployments(client.Page{}); err != nil {
+					return fmt.Errorf("list deployments: %w", err)
+				}
+				fetched = true
+			}
+			for _, d := range deployments {
+				if d.ModelName == n.Name && d.ModelVersion == n.Version {
+					n.Downstream = append(n.Downstream, &lineageNode{Kind: "deployment", Name: d.Name})
+				}
+			}
+		}
+		for _, child := range n.Downstream {
+			if err := walk(child, level+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(root, 0)
+}
+
+// lineageTree renders root with its upstream and downstream branches.
+func lineageTree(root *lineageNode) string {
+	var sb strings.Builder
+	sb.WriteString(root.String() + "\n")
+
+	type section struct {
+		label string
+		nodes []*lineageNode
+	}
+	var sections []section
+	if len(root.Upstream) > 0 {
+		sections = append(sections, section{"upstream", root.Upstream})
+	}
+	if len(root.Downstream) > 0 {
+		sections = append(sections, section{"downstream", root.Downstream})
+	}
+
+	var branch func(n *lineageNode, prefix string, last bool)
+	branch = func(n *lineageNode, prefix string, last bool) {
+		connector, indent := "├── ", "│   "
+		if last {
+			connector, indent = "└── ", "    "
+		}
+		sb.WriteString(prefix + connector + n.String() + "\n")
+		children := n.Downstream
+		if len(n.Upstream) > 0 {
+			children = n.Upstream
+		}
+		for i, child := range children {
+			branch(child, prefix+indent, i == len(children)-1)
+		}
+	}
+
+	for i, sec := range sections {
+		last := i == len(sections)-1
+		connector, indent := "├── ", "│   "
+		if last {
+			connector, indent = "└── ", "    "
+		}
+		sb.WriteString(connector + sec.label + "\n")
+		for j, n := range sec.nodes {
+			branch(n, indent, j == len(sec.nodes)-1)
+		}
+	}
+	return sb.String()
+}
+
+// lineageEdges flattens the tree into unique nodes and data-flow edges
+// (source → derived), in the order they are first seen.
+func lineageEdges(root *lineageNode) (nodes []*lineageNode, edges [][2]*lineageNode) {
+	seen := map[string]*lineageNode{}
+	seenEdge := map[string]bool{}
+	visit := func(n *lineageNode) *lineageNode {
+		if first, ok := seen[n.key()]; ok {
+			return first
+		}
+		seen[n.key()] = n
+		nodes = append(nodes, n)
+		return n
+	}
+	edge := func(from, to *lineageNode) {
+		k := from.key() + "→" + to.key()
+		if !seenEdge[k] {
+			seenEdge[k] = true
+			edges = append(edges, [2]*lineageNode{from, to})
+		}
+	}
+
+	var walk func(n *lineageNode)
+	walk = func(n *lineageNode) {
+		for _, u := range n.Upstream {
+			edge(visit(u), seen[n.key()])
+			walk(u)
+		}
+		for _, d := range n.Downstream {
+			edge(seen[n.key()], visit(d))
+			walk(d)
+		}
+	}
+	visit(root)
+	walk(root)
+	return nodes, edges
+}
+
+func lineageLabel(n *lineageNode) string {
+	label := n.Name
+	if n.Version > 0 {
+		label += fmt.Sprintf(" v%d", n.Version)
+	}
+	kind := artifactKindLabels[n.Kind]
+	if kind == "" {
+		kind = n.Kind
+	}
+	if n.Status != "" {
+		kind += ", " + n.Status
+	}
+	return label + "\n" + kind
+}
+
+func lineageDOT(root *lineageNode) string {
+	nodes, edges := lineageEdges(root)
+	ids := make(map[*lineageNode]string, len(nodes))
+
+	var sb strings.Builder
+	sb.WriteString("digraph lineage {\n  rankdir=LR;\n  node [shape=box];\n")
+	for i, n := range nodes {
+		ids[n] = fmt.Sprintf("n%d", i)
+		var attrs []string
+		attrs = append(attrs, fmt.Sprintf("label=%q", lineageLabel(n)))
+		if n == root {
+			attrs = append(attrs, "style=bold")
+		}
+		if n.Status != "" {
+			attrs = append(attrs, "style=dashed")
+		}
+		sb.WriteString(fmt.Sprintf("  %s [%s];\n", ids[n], strings.Join(attrs, ", ")))
+	}
+	for _, e := range edges {
+		sb.WriteString(fmt.Sprintf("  %s -> %s;\n", ids[e[0]], ids[e[1]]))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func lineageMermaid(root *lineageNode) string {
+	nodes, edges := lineageEdges(root)
+	ids := make(map[*lineageNode]string, len(nodes))
+
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	for i, n := range nodes {
+		ids[n] = fmt.Sprintf("n%d", i)
+		label := strings.ReplaceAll(lineageLabel(n), `"`, "#quot;")
+		label = strings.ReplaceAll(label, "\n", "<br/>")
+		sb.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", ids[n], label))
+	}
+	for _, e := range edges {
+		sb.WriteString(fmt.Sprintf("  %s --> %s\n", ids[e[0]], ids[e[1]]))
+	}
+	sb.WriteString(fmt.Sprintf("  style %s stroke-width:3px\n", ids[root]))
+	for _, n := range nodes {
+		if n.Status != "" {
+			sb.WriteString(fmt.Sprintf("  style %s stroke-dasharray:5 5\n", ids[n]))
+		}
+	}
+	return sb.String()
+}
+
+func init() {
+	lineageCmd.Flags().IntVar(&lineageVersion, "version", 0, "Artifact version (latest if omitted; feature view version for td)")
+	lineageCmd.Flags().IntVar(&lineageTDVersion, "td-version", 0, "Training dataset version (td only)")
+	lineageCmd.Flags().IntVar(&lineageDepth, "depth", 3, "Levels to follow in each direction")
+	lineageCmd.Flags().StringVar(&lineageDirection, "direction", "both", "Direction to walk: up, down or both")
+	lineageCmd.Flags().StringVar(&lineageFormat, "format", "tree", "Render as tree, dot or mermaid")
+
+	rootCmd.AddCommand(lineageCmd)
+}
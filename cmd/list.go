@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
+	"github.com/MagicLex/hopsworks-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// Shared by every `list` command
+var (
+	listLimit   int
+	listOffset  int
+	listAll     bool
+	listColumns []string
+	listSortBy  string
+	listWide    bool
+)
+
+// addListFlags registers the paging (--limit, --offset, --all) and table
+// (--columns, --sort-by, --wide) flags on a list command.
+func addListFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&listLimit, "limit", client.PageSize, "Maximum number of items to show")
+	cmd.Flags().IntVar(&listOffset, "offset", 0, "Number of items to skip")
+	cmd.Flags().BoolVar(&listAll, "all", false, "Fetch every item (ignores --limit)")
+	cmd.Flags().StringSliceVar(&listColumns, "columns", nil, "Comma-separated columns to show (e.g. name,version)")
+	cmd.Flags().StringVar(&listSortBy, "sort-by", "", "Sort by a column; prefix with '-' for descending (e.g. -version)")
+	cmd.Flags().BoolVar(&listWide, "wide", false, "Show extra columns (IDs, creation time, location, ...)")
+}
+
+// printList prints a fetched page through the shared list columns, applying
+// --columns, --sort-by and --wide. Bad column names are usage errors.
+func printList[T any](items []T, cols []output.Column[T]) error {
+	opts := output.ListOptions{Columns: listColumns, SortBy: listSortBy, Wide: listWide}
+	if err := output.PrintList(items, cols, opts); err != nil {
+		return &usageError{err}
+	}
+	return nil
+}
+
+// listPage turns the list flags into a client.Page. It asks for one item more
+// than --limit so trimPage can tell whether the listing was cut short.
+func listPage() client.Page {
+	page := client.Page{Offset: listOffset}
+	if !listAll && listLimit > 0 {
+		page.Limit = listLimit + 1
+	}
+	return page
+}
+
+// trimPage drops the probe item requested by listPage and, if there was one,
+// warns that more items are available.
+func trimPage[T any](items []T) []T {
+	if listAll || listLimit <= 0 || len(items) <= listLimit {
+		return items
+	}
+	output.Warn("showing %d items from offset %d; more available (use --all or --offset %d)",
+		listLimit, listOffset, listOffset+listLimit)
+	return items[:listLimit]
+}
+
+// activeMark is the ACTIVE cell of project and feature store lists.
+func activeMark(active bool) interface{} {
+	if active {
+		return "*"
+	}
+	return nil
+}
+
+// apiTime turns an API timestamp (RFC 3339 string or epoch millis) into a
+// time.Time so list columns sort chronologically. Other values pass through.
+func apiTime(v interface{}) interface{} {
+	switch t := v.(type) {
+	case string:
+		if t == "" {
+			return nil
+		}
+		if ts, err := time.Parse(time.RFC3339, t); err == nil {
+			return ts.Local()
+		}
+		return t
+	case int64:
+		if t == 0 {
+			return nil
+		}
+		return time.UnixMilli(t)
+	case float64:
+		if t == 0 {
+			return nil
+		}
+		return time.UnixMilli(int64(t))
+	}
+	return v
+}
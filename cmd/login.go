@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/MagicLex/hopsworks-cli/pkg/client"
+	"github.com/MagicLex/hopsworks-cli/pkg/config"
 	"github.com/MagicLex/hopsworks-cli/pkg/output"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -18,10 +19,18 @@ import (
 
 const defaultHost = "https://eu-west.cloud.hopsworks.ai"
 
+var loginNoStore bool
+
 var loginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Authenticate with Hopsworks",
-	Long:  `Login to a Hopsworks instance. Validates the API key and saves config to ~/.hops/config.`,
+	Long: `Login to a Hopsworks instance. Validates the API key and saves config to ~/.hops/config.
+
+With a credential_helper configured, the key is fetched from the helper and never written.
+Neither is a key from HOPSWORKS_API_KEY; only one typed at the prompt or passed as
+'hops login --api-key' is saved. Other commands never write --api-key to disk.
+Use --no-store to keep the key out of the config file (supply it later via
+HOPSWORKS_API_KEY or a credential helper).`,
 	RunE: loginRun,
 }
 
@@ -42,6 +51,17 @@ func loginRun(cmd *cobra.Command, args []string) error {
 			return err
 		}
 		cfg.Host = host
+		cfg.Origins["host"] = config.OriginLogin
+	}
+
+	// Values passed to login itself are meant to be saved; ones from the env are not
+	for _, key := range []string{"host", "api_key", "project", "ca_bundle"} {
+		if strings.HasPrefix(cfg.Origins[key], "flag ") {
+			cfg.Origins[key] = config.OriginLogin
+		}
+	}
+	if err := cfg.ResolveAPIKey(); err != nil {
+		return err
 	}
 
 	// Prompt for API key if not set
@@ -51,15 +71,16 @@ func loginRun(cmd *cobra.Command, args []string) error {
 			return err
 		}
 		cfg.APIKey = apiKey
+		cfg.Origins["api_key"] = config.OriginLogin
 	}
 
 	// Validate by fetching projects
-	c, err := client.New(cfg)
+	c, err := newClient()
 	if err != nil {
 		return err
 	}
 
-	projects, err := c.ListProjects()
+	projects, err := c.ListProjects(client.Page{})
 	if err != nil {
 		return fmt.Errorf("authenticate: %w", err)
 	}
@@ -81,15 +102,28 @@ func loginRun(cmd *cobra.Command, args []string) error {
 			cfg.ProjectID = selected.ProjectID
 			output.Success("Selected project: %s", cfg.Project)
 		}
+		cfg.Origins["project"], cfg.Origins["project_id"] = config.OriginLogin, config.OriginLogin
 
 		if err := resolveFeatureStoreID(c); err == nil {
 			// resolved, will be saved below
 		}
 	}
 
+	if loginNoStore {
+		apiKey := cfg.APIKey
+		cfg.APIKey = ""
+		defer func() { cfg.APIKey = apiKey }()
+	}
 	if err := cfg.Save(); err != nil {
 		return fmt.Errorf("save config: %w", err)
 	}
+	output.Info("Saved to context '%s'", cfg.Context)
+	if origin := cfg.Origins["api_key"]; !loginNoStore && strings.HasPrefix(origin, "env ") {
+		output.Info("API key from %s not stored", strings.TrimPrefix(origin, "env "))
+	}
+	if loginNoStore && cfg.CredentialHelper == "" {
+		output.Info("API key not stored. Set HOPSWORKS_API_KEY or credential_helper for later commands")
+	}
 
 	return nil
 }
@@ -208,6 +242,16 @@ func resolveFeatureStoreID(c *client.Client) error {
 	if err := json.Unmarshal(data, &stores); err != nil {
 		return err
 	}
+	// Pinned by name (.hops.yaml / config)
+	if cfg.FeatureStore != "" {
+		for _, s := range stores {
+			if s.Name == cfg.FeatureStore {
+				cfg.FeatureStoreID = s.FeaturestoreId
+				return nil
+			}
+		}
+		return fmt.Errorf("feature store '%s' not found in project %s", cfg.FeatureStore, cfg.Project)
+	}
 	for _, s := range stores {
 		if strings.Contains(s.Name, cfg.Project) || len(stores) == 1 {
 			cfg.FeatureStoreID = s.FeaturestoreId
@@ -221,5 +265,6 @@ func resolveFeatureStoreID(c *client.Client) error {
 }
 
 func init() {
+	loginCmd.Flags().BoolVar(&loginNoStore, "no-store", false, "Don't write the API key to ~/.hops/config")
 	rootCmd.AddCommand(loginCmd)
 }
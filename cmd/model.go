@@ -2,9 +2,9 @@ package cmd
 
 import (
 	"fmt"
-	"strconv"
 	"time"
 
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
 	"github.com/MagicLex/hopsworks-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -16,6 +16,16 @@ var modelCmd = &cobra.Command{
 	Short: "Manage models in the model registry",
 }
 
+var modelListColumns = []output.Column[client.Model]{
+	{Name: "NAME", Value: func(m client.Model) interface{} { return m.Name }},
+	{Name: "VERSION", Value: func(m client.Model) interface{} { return m.Version }},
+	{Name: "FRAMEWORK", Value: func(m client.Model) interface{} { return m.Framework }},
+	{Name: "CREATED", Value: func(m client.Model) interface{} { return apiTime(m.Created) }},
+	{Name: "DESCRIPTION", Flex: true, Value: func(m client.Model) interface{} { return m.Description }},
+	{Name: "ID", Wide: true, Value: func(m client.Model) interface{} { return m.ID }},
+	{Name: "CREATOR", Wide: true, Value: func(m client.Model) interface{} { return m.UserFullName }},
+}
+
 var modelListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List models",
@@ -25,29 +35,13 @@ var modelListCmd = &cobra.Command{
 			return err
 		}
 
-		models, err := c.ListModels()
+		models, err := c.ListModels(listPage())
 		if err != nil {
 			return err
 		}
+		models = trimPage(models)
 
-		if output.JSONMode {
-			output.PrintJSON(models)
-			return nil
-		}
-
-		headers := []string{"NAME", "VERSION", "FRAMEWORK", "CREATED", "DESCRIPTION"}
-		var rows [][]string
-		for _, m := range models {
-			rows = append(rows, []string{
-				m.Name,
-				strconv.Itoa(m.Version),
-				m.Framework,
-				fmtEpochMs(m.Created),
-				truncate(m.Description, 40),
-			})
-		}
-		output.Table(headers, rows)
-		return nil
+		return printList(models, modelListColumns)
 	},
 }
 
@@ -90,9 +84,9 @@ var modelInfoCmd = &cobra.Command{
 			output.Info("")
 			output.Info("Metrics:")
 			headers := []string{"METRIC", "VALUE"}
-			var rows [][]string
+			var rows []output.Row
 			for k, v := range m.Metrics {
-				rows = append(rows, []string{k, fmt.Sprintf("%.6g", v)})
+				rows = append(rows, output.Row{k, v})
 			}
 			output.Table(headers, rows)
 		}
@@ -142,6 +136,7 @@ func init() {
 	modelInfoCmd.Flags().IntVar(&modelVersion, "version", 0, "Model version (latest if omitted)")
 	modelDeleteCmd.Flags().IntVar(&modelVersion, "version", 0, "Model version to delete")
 
+	addListFlags(modelListCmd)
 	modelCmd.AddCommand(modelListCmd)
 	modelCmd.AddCommand(modelInfoCmd)
 	modelCmd.AddCommand(modelDeleteCmd)
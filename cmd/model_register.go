@@ -46,12 +46,9 @@ Examples:
 		name := args[0]
 		path := args[1]
 
-		if !output.JSONMode {
-			output.Info("Registering model '%s' from %s...", name, path)
-		}
-
 		script := buildModelRegisterScript(name, path)
-		if err := runPython(script); err != nil {
+		title := fmt.Sprintf("Registering model '%s' from %s", name, path)
+		if err := runPythonProgress(title, script); err != nil {
 			return fmt.Errorf("register model: %w", err)
 		}
 		return nil
@@ -216,8 +213,9 @@ var modelDownloadCmd = &cobra.Command{
 
 Examples:
   hops model download fraud_detector
-  hops model download fraud_detector --version 1 --output ./local_dir`,
-	Args: cobra.ExactArgs(1),
+  hops model download fraud_detector --version 1 --dir ./local_dir`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{legacyOutputFlag: "dir"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 
@@ -275,7 +273,7 @@ func init() {
 	modelRegisterCmd.Flags().StringVar(&modelRegProgram, "program", "", "Training script path (stored as metadata)")
 
 	modelDownloadCmd.Flags().IntVar(&modelDownloadVer, "version", 0, "Model version (latest if omitted)")
-	modelDownloadCmd.Flags().StringVar(&modelDownloadOutput, "output", "", "Download directory")
+	modelDownloadCmd.Flags().StringVar(&modelDownloadOutput, "dir", "", "Download directory")
 
 	modelCmd.AddCommand(modelRegisterCmd)
 	modelCmd.AddCommand(modelDownloadCmd)
@@ -2,9 +2,9 @@ package cmd
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/MagicLex/hopsworks-cli/pkg/client"
+	"github.com/MagicLex/hopsworks-cli/pkg/config"
 	"github.com/MagicLex/hopsworks-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -14,39 +14,29 @@ var projectCmd = &cobra.Command{
 	Short: "Manage projects",
 }
 
+var projectListColumns = []output.Column[client.Project]{
+	{Name: "NAME", Value: func(p client.Project) interface{} { return p.ProjectName }},
+	{Name: "ID", Value: func(p client.Project) interface{} { return p.ProjectID }},
+	{Name: "ACTIVE", Value: func(p client.Project) interface{} { return activeMark(p.ProjectName == cfg.Project) }},
+	{Name: "CREATED", Wide: true, Value: func(p client.Project) interface{} { return apiTime(p.Created) }},
+	{Name: "DESCRIPTION", Wide: true, Flex: true, Value: func(p client.Project) interface{} { return p.Description }},
+}
+
 var projectListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List accessible projects",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		c, err := client.New(cfg)
+		c, err := newClient()
 		if err != nil {
 			return err
 		}
-		projects, err := c.ListProjects()
+		projects, err := c.ListProjects(listPage())
 		if err != nil {
 			return err
 		}
+		projects = trimPage(projects)
 
-		if output.JSONMode {
-			output.PrintJSON(projects)
-			return nil
-		}
-
-		headers := []string{"NAME", "ID", "ACTIVE"}
-		var rows [][]string
-		for _, p := range projects {
-			active := ""
-			if p.ProjectName == cfg.Project {
-				active = "*"
-			}
-			rows = append(rows, []string{
-				p.ProjectName,
-				strconv.Itoa(p.ProjectID),
-				active,
-			})
-		}
-		output.Table(headers, rows)
-		return nil
+		return printList(projects, projectListColumns)
 	},
 }
 
@@ -57,7 +47,7 @@ var projectUseCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 
-		c, err := client.New(cfg)
+		c, err := newClient()
 		if err != nil {
 			return err
 		}
@@ -69,6 +59,7 @@ var projectUseCmd = &cobra.Command{
 
 		cfg.Project = project.ProjectName
 		cfg.ProjectID = project.ProjectID
+		cfg.Origins["project"], cfg.Origins["project_id"] = config.OriginProjectUse, config.OriginProjectUse
 
 		// Resolve feature store ID
 		if err := resolveFeatureStoreID(c); err != nil {
@@ -91,7 +82,7 @@ var projectInfoCmd = &cobra.Command{
 	Use:   "info [name]",
 	Short: "Show project details",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		c, err := client.New(cfg)
+		c, err := newClient()
 		if err != nil {
 			return err
 		}
@@ -119,12 +110,13 @@ var projectInfoCmd = &cobra.Command{
 		}
 		output.Table(
 			[]string{"FIELD", "VALUE"},
-			[][]string{
+			[]output.Row{
 				{"Name", project.ProjectName},
-				{"ID", strconv.Itoa(project.ProjectID)},
+				{"ID", project.ProjectID},
 				{"Description", project.Description},
 				{"Created", project.Created},
 				{"Mode", cfg.Mode()},
+				{"Context", cfg.Context},
 				{"Auth", auth},
 			},
 		)
@@ -134,6 +126,7 @@ var projectInfoCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(projectCmd)
+	addListFlags(projectListCmd)
 	projectCmd.AddCommand(projectListCmd)
 	projectCmd.AddCommand(projectUseCmd)
 	projectCmd.AddCommand(projectInfoCmd)
@@ -1,8 +1,12 @@
 package cmd
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/MagicLex/hopsworks-cli/pkg/config"
 	"github.com/MagicLex/hopsworks-cli/pkg/output"
@@ -13,11 +17,26 @@ import (
 var Version = "0.8.8"
 
 var (
-	cfg       *config.Config
-	flagHost   string
-	flagAPIKey string
-	flagProject string
-	flagJSON   bool
+	cfg          *config.Config
+	flagHost     string
+	flagAPIKey   string
+	flagProject  string
+	flagContext  string
+	flagJSON     bool
+	flagOutput   string
+	flagTimeout  time.Duration
+	flagRetries  int
+	flagCACert   string
+	flagInsecure bool
+	flagTrustPin bool
+	flagDebug    bool
+	flagDebugDir string
+
+	// commandStarted is set once flags and args parsed, so earlier failures count as usage errors
+	commandStarted bool
+
+	// cmdCtx is cancelled on Ctrl-C / SIGTERM so in-flight requests abort
+	cmdCtx = context.Background()
 )
 
 var rootCmd = &cobra.Command{
@@ -27,34 +46,140 @@ var rootCmd = &cobra.Command{
 	Long: `hops is a CLI for the Hopsworks Feature Store platform.
 
 Works for both humans (pretty tables) and LLMs (--json).
+Use -o yaml|csv|tsv|ndjson|markdown for other formats, or
+-o jsonpath=<expr> / -o go-template=<tmpl> to extract fields without jq.
 Run 'hops init' to set up Claude Code integration.`,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		output.JSONMode = flagJSON
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyOutputFormat(cmd); err != nil {
+			return err
+		}
+		commandStarted = true
+		// Past argument validation, failures are runtime errors — don't dump usage
+		cmd.SilenceUsage = true
 
 		// Load config
 		var err error
-		cfg, err = config.Load()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: could not load config: %v\n", err)
-			cfg = &config.Config{}
+		cfg, err = config.Load(flagContext)
+		var unknown *config.UnknownContextError
+		if errors.As(err, &unknown) && cmd != loginCmd && !localOnly(cmd) {
+			// 'hops login --context <new>' creates it
+			return err
+		}
+		if err != nil && unknown == nil {
+			output.Warn("could not load config: %v", err)
+			if cfg == nil {
+				cfg = &config.Config{Origins: map[string]string{}}
+			}
 		}
 
 		// CLI flags override config/env
 		if flagHost != "" {
 			cfg.Host = flagHost
+			cfg.Origins["host"] = "flag --host"
 		}
 		if flagAPIKey != "" {
 			cfg.APIKey = flagAPIKey
+			cfg.Origins["api_key"] = "flag --api-key"
 		}
-		if flagProject != "" {
+		if flagProject != "" && flagProject != cfg.Project {
+			// IDs loaded for another project don't apply; mustClient resolves them by name
 			cfg.Project = flagProject
+			cfg.ProjectID, cfg.FeatureStoreID, cfg.FeatureStore = 0, 0, ""
+			cfg.Origins["project"] = "flag --project"
+		}
+		if flagCACert != "" {
+			cfg.CABundle = flagCACert
+			cfg.Origins["ca_bundle"] = "flag --ca-cert"
+		}
+		if err := cfg.CheckPinnedHost(flagTrustPin); err != nil {
+			if !localOnly(cmd) {
+				return err
+			}
+			output.Warn("%v", err)
 		}
+		cfg.Insecure = flagInsecure
+		if flagInsecure {
+			output.Warn("TLS certificate verification disabled (--insecure)")
+		}
+		if flagDebug {
+			cfg.Debug = true
+		}
+		if flagDebugDir != "" {
+			cfg.DebugDir = flagDebugDir
+		}
+		cfg.Timeout = flagTimeout
+		cfg.Retries = flagRetries
+
+		applyPinnedVersion(cmd, args)
+		return nil
 	},
 }
 
+// localOnly reports whether cmd only reads or edits ~/.hops/config, so it
+// still runs when the effective connection settings are refused.
+func localOnly(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == configCmd {
+			return true
+		}
+	}
+	return false
+}
+
+// legacyOutputFlag names the local flag that took over --output <path> on
+// commands that had it before -o/--output became the global format flag.
+const legacyOutputFlag = "legacy-output-flag"
+
+// applyOutputFormat sets the output format from -o/--output, with --json as
+// shorthand for -o json. A path given to --output on a command annotated with
+// legacyOutputFlag is forwarded to that flag.
+func applyOutputFormat(cmd *cobra.Command) error {
+	format := flagOutput
+	if flagJSON && !cmd.Flags().Changed("output") {
+		format = output.FormatJSON
+	}
+	err := output.SetFormat(format)
+	if err == nil {
+		return nil
+	}
+	if name := cmd.Annotations[legacyOutputFlag]; name != "" {
+		if err := cmd.Flags().Set(name, flagOutput); err != nil {
+			return err
+		}
+		flagOutput = output.FormatTable
+		if flagJSON {
+			flagOutput = output.FormatJSON
+		}
+		output.Warn("--output <path> is deprecated here, use --%s", name)
+		return output.SetFormat(flagOutput)
+	}
+	return err
+}
+
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		// After the first signal, restore default handling so a second Ctrl-C kills immediately
+		<-ctx.Done()
+		stop()
+	}()
+	cmdCtx = ctx
+
+	err := rootCmd.ExecuteContext(ctx)
+	output.FlushWarnings()
+	if err != nil {
+		if !commandStarted {
+			// The format is only set in PersistentPreRunE; honour --json for usage errors too
+			if flagJSON {
+				output.SetFormat(output.FormatJSON)
+			} else if output.SetFormat(flagOutput) != nil {
+				output.SetFormat(output.FormatTable)
+			}
+			err = &usageError{err}
+		}
+		os.Exit(reportError(err))
 	}
 }
 
@@ -62,5 +187,14 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&flagHost, "host", "", "Hopsworks host URL")
 	rootCmd.PersistentFlags().StringVar(&flagAPIKey, "api-key", "", "Hopsworks API key")
 	rootCmd.PersistentFlags().StringVar(&flagProject, "project", "", "Project name")
-	rootCmd.PersistentFlags().BoolVar(&flagJSON, "json", false, "Output as JSON (for LLMs)")
+	rootCmd.PersistentFlags().StringVar(&flagContext, "context", "", "Config context to use (default: current context, or HOPS_CONTEXT)")
+	rootCmd.PersistentFlags().BoolVar(&flagJSON, "json", false, "Output as JSON (for LLMs); same as -o json")
+	rootCmd.PersistentFlags().StringVarP(&flagOutput, "output", "o", output.FormatTable, "Output format: table|json|yaml|csv|tsv|ndjson|markdown, jsonpath=<expr>, go-template=<tmpl>")
+	rootCmd.PersistentFlags().DurationVar(&flagTimeout, "timeout", 30*time.Second, "Per-request HTTP timeout")
+	rootCmd.PersistentFlags().IntVar(&flagRetries, "retries", 3, "Retries for transient API failures (0 disables)")
+	rootCmd.PersistentFlags().StringVar(&flagCACert, "ca-cert", "", "PEM CA bundle to trust for the Hopsworks host")
+	rootCmd.PersistentFlags().BoolVar(&flagDebug, "debug", false, "Trace HTTP requests to stderr (secrets redacted)")
+	rootCmd.PersistentFlags().StringVar(&flagDebugDir, "debug-dir", "", "Also write full request/response bodies to this directory")
+	rootCmd.PersistentFlags().BoolVar(&flagInsecure, "insecure", false, "Skip TLS certificate verification (not recommended)")
+	rootCmd.PersistentFlags().BoolVar(&flagTrustPin, "trust-project-host", false, "Use the host pinned by .hops.yaml even if it differs from the context's")
 }
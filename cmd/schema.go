@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
+	"github.com/MagicLex/hopsworks-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// outputShape is one document a command prints in json/yaml: its kind and a
+// value of the Go type it is marshalled from.
+type outputShape struct {
+	kind   string
+	sample interface{}
+}
+
+// shape names the output after sample's type, as PrintJSON does.
+func shape(sample interface{}) outputShape {
+	return outputShape{output.KindOf(sample), sample}
+}
+
+// outputShapes lists what each command prints with --json. Commands with more
+// than one shape pick one by flag (e.g. --compute, --dry-run).
+var outputShapes = map[*cobra.Command][]outputShape{
+	chartListCmd:     {shape([]client.Chart(nil))},
+	chartInfoCmd:     {shape((*client.Chart)(nil))},
+	chartCreateCmd:   {shape((*client.Chart)(nil))},
+	chartUpdateCmd:   {shape((*client.Chart)(nil))},
+	chartGenerateCmd: {shape((*client.Chart)(nil))},
+
+	configGetContextsCmd: {shape([]contextInfo(nil))},
+	configViewCmd:        {shape([]configValue(nil))},
+
+	connectorListCmd:            {shape([]client.StorageConnector(nil))},
+	connectorInfoCmd:            {shape((*client.StorageConnector)(nil))},
+	connectorTestCmd:            {shape((*connectorTestResult)(nil))},
+	connectorDatabasesCmd:       {{"Database", []string(nil)}},
+	connectorTablesCmd:          {shape([]client.DataSource(nil))},
+	connectorPreviewCmd:         {{"Row", []map[string]interface{}(nil)}},
+	connectorCreateSnowflakeCmd: {shape((*client.StorageConnector)(nil))},
+	connectorCreateJDBCCmd:      {shape((*client.StorageConnector)(nil))},
+	connectorCreateS3Cmd:        {shape((*client.StorageConnector)(nil))},
+	connectorCreateBigQueryCmd:  {shape((*client.StorageConnector)(nil))},
+
+	contextCmd: {shape((*projectContext)(nil))},
+
+	dashboardListCmd:        {shape([]client.Dashboard(nil))},
+	dashboardInfoCmd:        {shape((*client.Dashboard)(nil))},
+	dashboardCreateCmd:      {shape((*client.Dashboard)(nil))},
+	dashboardAddChartCmd:    {shape((*client.Dashboard)(nil))},
+	dashboardRemoveChartCmd: {shape((*client.Dashboard)(nil))},
+
+	datasetListCmd: {shape([]client.DatasetFile(nil))},
+
+	deployListCmd:    {shape([]client.Deployment(nil))},
+	deployInfoCmd:    {shape((*client.Deployment)(nil))},
+	deployCreateCmd:  {shape((*client.Deployment)(nil))},
+	deployPredictCmd: {{"Prediction", json.RawMessage(nil)}},
+
+	fgListCmd:           {shape([]client.FeatureGroup(nil))},
+	fgInfoCmd:           {shape((*client.FeatureGroup)(nil))},
+	fgCreateCmd:         {shape((*client.FeatureGroup)(nil)), shape((*client.CreateFeatureGroupRequest)(nil))},
+	fgUpdateCmd:         {shape((*client.FeatureGroup)(nil)), shape([]schemaChange(nil))},
+	fgDiffCmd:           {shape([]schemaChange(nil))},
+	fgCommitsCmd:        {shape([]client.Commit(nil))},
+	fgConsistencyCmd:    {shape((*consistencyReport)(nil))},
+	fgDeleteCmd:         {shape((*deleteResult)(nil))},
+	fgExpectationsCmd:   {shape((*client.ExpectationSuite)(nil))},
+	fgValidationsCmd:    {shape([]client.ValidationReport(nil)), shape((*client.ValidationReport)(nil))},
+	fgValidateCmd:       {shape((*validateResult)(nil))},
+	fgPreviewCmd:        {{"Row", []map[string]interface{}(nil)}},
+	fgFeaturesCmd:       {shape([]client.Feature(nil))},
+	fgStatsCmd:          {shape((*client.Statistics)(nil)), shape((*client.ComputeJobResponse)(nil))},
+	fgKeywordsCmd:       {{"Keyword", []string(nil)}},
+	fgAddKeywordCmd:     {{"Keyword", []string(nil)}},
+	fgRemoveKeywordCmd:  {{"Keyword", []string(nil)}},
+	fgDeriveCmd:         {shape((*deriveResult)(nil))},
+	fgCreateExternalCmd: {shape((*externalFGResult)(nil))},
+	fgInsertCmd:         {shape((*insertResult)(nil)), shape((*insertCheck)(nil)), shape((*chunkedInsertResult)(nil)), shape((*generatePlan)(nil))},
+	fgSearchCmd:         {{"Neighbor", []map[string]interface{}(nil)}},
+
+	fsListCmd: {shape([]client.FeatureStore(nil))},
+
+	fvListCmd:   {shape([]client.FeatureView(nil))},
+	fvInfoCmd:   {shape((*client.FeatureView)(nil))},
+	fvCreateCmd: {shape((*client.FeatureView)(nil))},
+	fvDeleteCmd: {shape((*deleteResult)(nil))},
+	fvGetCmd:    {{"FeatureVector", []map[string]interface{}(nil)}},
+	fvReadCmd:   {{"Row", []map[string]interface{}(nil)}, shape((*dataFile)(nil))},
+
+	jobListCmd:         {shape([]client.Job(nil))},
+	jobInfoCmd:         {shape((*client.Job)(nil))},
+	jobCreateCmd:       {shape((*client.Job)(nil))},
+	jobRunCmd:          {shape((*client.Execution)(nil))},
+	jobStopCmd:         {shape((*client.Execution)(nil))},
+	jobStatusCmd:       {shape((*client.Execution)(nil))},
+	jobLogsCmd:         {shape((*client.ExecutionLog)(nil))},
+	jobHistoryCmd:      {shape([]client.Execution(nil))},
+	jobDeleteCmd:       {shape((*jobDeleteResult)(nil))},
+	jobScheduleCmd:     {shape((*client.JobSchedule)(nil))},
+	jobScheduleInfoCmd: {shape((*client.JobSchedule)(nil))},
+	jobUnscheduleCmd:   {shape((*jobUnscheduleResult)(nil))},
+
+	lineageCmd: {shape((*lineageNode)(nil))},
+
+	modelListCmd: {shape([]client.Model(nil))},
+	modelInfoCmd: {shape((*client.Model)(nil))},
+
+	projectListCmd: {shape([]client.Project(nil))},
+	projectInfoCmd: {shape((*client.Project)(nil))},
+
+	tdListCmd:   {shape([]client.TrainingDataset(nil))},
+	tdCreateCmd: {shape((*client.TrainingDataset)(nil))},
+	tdDeleteCmd: {shape((*deleteResult)(nil))},
+	tdReadCmd:   {{"Row", []map[string]interface{}(nil)}, shape((*dataFile)(nil))},
+	tdStatsCmd:  {shape((*client.Statistics)(nil))},
+
+	tfListCmd:   {shape([]client.TransformationFunction(nil))},
+	tfCreateCmd: {shape((*client.TransformationFunction)(nil))},
+}
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema [command...]",
+	Short: "Print the JSON Schema of a command's --json output",
+	Long: `Print the JSON Schema of what a command prints with --json (or -o yaml),
+envelope included. Without arguments, list the commands that have one.
+
+Every json/yaml document is an envelope:
+  {"apiVersion": "` + output.APIVersion + `", "kind": "FeatureGroupList", "items": [...], "warnings": [...]}
+Single objects come in "item" instead of "items".
+
+Examples:
+  hops schema
+  hops schema fg list
+  hops schema job status > job-status.schema.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return listSchemas()
+		}
+
+		target, rest, err := rootCmd.Find(args)
+		if err != nil || len(rest) > 0 || target == rootCmd {
+			return &usageError{fmt.Errorf("unknown command %q", strings.Join(args, " "))}
+		}
+		shapes, ok := outputShapes[target]
+		if !ok {
+			return fmt.Errorf("'%s' has no structured output", target.CommandPath())
+		}
+
+		defs := map[string]interface{}{}
+		var alternatives []interface{}
+		for _, s := range shapes {
+			alternatives = append(alternatives, output.EnvelopeSchema(s.kind, s.sample, defs))
+		}
+		doc := map[string]interface{}{
+			"$schema":     output.SchemaDraft,
+			"title":       target.CommandPath(),
+			"description": target.Short,
+		}
+		if len(alternatives) == 1 {
+			for k, v := range alternatives[0].(map[string]interface{}) {
+				doc[k] = v
+			}
+		} else {
+			doc["oneOf"] = alternatives
+		}
+		if len(defs) > 0 {
+			doc["$defs"] = defs
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	},
+}
+
+// listSchemas prints every command that has an output schema, with its kinds.
+func listSchemas() error {
+	type entry struct{ path, kinds string }
+	var entries []entry
+	for c, shapes := range outputShapes {
+		var kinds []string
+		for _, s := range shapes {
+			kind := s.kind
+			if s.sample != nil && strings.HasPrefix(fmt.Sprintf("%T", s.sample), "[]") {
+				kind += "List"
+			}
+			kinds = append(kinds, kind)
+		}
+		path := strings.TrimPrefix(c.CommandPath(), rootCmd.Name()+" ")
+		entries = append(entries, entry{path, strings.Join(kinds, " | ")})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].path < entries[j].path })
+
+	rows := make([]output.Row, len(entries))
+	for i, e := range entries {
+		rows[i] = output.Row{e.path, e.kinds}
+	}
+	output.Table([]string{"COMMAND", "KIND"}, rows)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
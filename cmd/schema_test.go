@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// noStructuredOutput lists the commands that print nothing with --json, so
+// have no outputShapes entry. A new command goes in one or the other.
+var noStructuredOutput = map[*cobra.Command]bool{
+	chartDeleteCmd:         true,
+	configDeleteContextCmd: true,
+	configSetContextCmd:    true,
+	configUseContextCmd:    true,
+	connectorDeleteCmd:     true,
+	dashboardDeleteCmd:     true,
+	datasetMkdirCmd:        true,
+	deployDeleteCmd:        true,
+	deployLogsCmd:          true,
+	deployStartCmd:         true,
+	deployStopCmd:          true,
+	initCmd:                true,
+	loginCmd:               true,
+	modelDeleteCmd:         true,
+	modelDownloadCmd:       true,
+	modelRegisterCmd:       true,
+	projectUseCmd:          true,
+	schemaCmd:              true, // Prints a JSON Schema, not an envelope
+	tdComputeCmd:           true,
+	updateCmd:              true,
+}
+
+func TestOutputShapesCoverCommands(t *testing.T) {
+	reachable := map[*cobra.Command]bool{}
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		reachable[c] = true
+		if c.Runnable() && outputShapes[c] == nil && !noStructuredOutput[c] {
+			t.Errorf("'%s' has no outputShapes entry (add one, or list it in noStructuredOutput)", c.CommandPath())
+		}
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(rootCmd)
+
+	for c, shapes := range outputShapes {
+		if !reachable[c] {
+			t.Errorf("outputShapes has '%s', which isn't a command under %s", c.Use, rootCmd.Name())
+		}
+		if noStructuredOutput[c] {
+			t.Errorf("'%s' is in both outputShapes and noStructuredOutput", c.CommandPath())
+		}
+		for _, s := range shapes {
+			if s.kind == "" {
+				t.Errorf("'%s' has an output shape without a kind", c.CommandPath())
+			}
+		}
+	}
+	for c := range noStructuredOutput {
+		if !reachable[c] {
+			t.Errorf("noStructuredOutput has '%s', which isn't a command under %s", c.Use, rootCmd.Name())
+		}
+	}
+}
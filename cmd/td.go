@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/MagicLex/hopsworks-cli/pkg/client"
 	"github.com/MagicLex/hopsworks-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -14,6 +15,16 @@ var tdCmd = &cobra.Command{
 	Short: "Manage training datasets",
 }
 
+var tdListColumns = []output.Column[client.TrainingDataset]{
+	{Name: "VERSION", Value: func(td client.TrainingDataset) interface{} { return td.Version }},
+	{Name: "FORMAT", Value: func(td client.TrainingDataset) interface{} { return td.DataFormat }},
+	{Name: "DESCRIPTION", Flex: true, Value: func(td client.TrainingDataset) interface{} { return td.Description }},
+	{Name: "CREATED", Value: func(td client.TrainingDataset) interface{} { return apiTime(td.Created) }},
+	{Name: "ID", Wide: true, Value: func(td client.TrainingDataset) interface{} { return td.ID }},
+	{Name: "NAME", Wide: true, Value: func(td client.TrainingDataset) interface{} { return td.Name }},
+	{Name: "LOCATION", Wide: true, Flex: true, Value: func(td client.TrainingDataset) interface{} { return td.Location }},
+}
+
 var tdListCmd = &cobra.Command{
 	Use:   "list <fv-name> <fv-version>",
 	Short: "List training datasets for a feature view",
@@ -29,28 +40,13 @@ var tdListCmd = &cobra.Command{
 			return err
 		}
 
-		tds, err := c.ListTrainingDatasets(args[0], fvVer)
+		tds, err := c.ListTrainingDatasets(args[0], fvVer, listPage())
 		if err != nil {
 			return err
 		}
+		tds = trimPage(tds)
 
-		if output.JSONMode {
-			output.PrintJSON(tds)
-			return nil
-		}
-
-		headers := []string{"VERSION", "FORMAT", "DESCRIPTION", "CREATED"}
-		var rows [][]string
-		for _, td := range tds {
-			rows = append(rows, []string{
-				strconv.Itoa(td.Version),
-				td.DataFormat,
-				truncate(td.Description, 30),
-				td.Created,
-			})
-		}
-		output.Table(headers, rows)
-		return nil
+		return printList(tds, tdListColumns)
 	},
 }
 
@@ -89,17 +85,33 @@ var tdCreateCmd = &cobra.Command{
 }
 
 var tdDeleteCmd = &cobra.Command{
-	Use:   "delete <fv-name> <fv-version> <td-version>",
+	Use:   "delete <fv-name> <fv-version> [td-version]",
 	Short: "Delete a training dataset",
-	Args:  cobra.ExactArgs(3),
+	Long: `Delete a training dataset version, or all of a feature view's training
+datasets with --all-versions.
+
+Before deleting, lists the models trained on it and the deployments serving
+them. If there are any, asks for confirmation, or needs --force when not run
+from a terminal.
+
+Examples:
+  hops td delete my_view 1 2 --dry-run
+  hops td delete my_view 1 2
+  hops td delete my_view 1 --all-versions --force`,
+	Args: cobra.RangeArgs(2, 3),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		fvVer, err := strconv.Atoi(args[1])
 		if err != nil {
 			return fmt.Errorf("invalid fv version: %s", args[1])
 		}
-		tdVer, err := strconv.Atoi(args[2])
-		if err != nil {
-			return fmt.Errorf("invalid td version: %s", args[2])
+		if (len(args) == 3) == deleteAllVersions {
+			return fmt.Errorf("one of <td-version> or --all-versions is required for delete")
+		}
+		var tdVer int
+		if len(args) == 3 {
+			if tdVer, err = strconv.Atoi(args[2]); err != nil {
+				return fmt.Errorf("invalid td version: %s", args[2])
+			}
 		}
 
 		c, err := mustClient()
@@ -107,12 +119,37 @@ var tdDeleteCmd = &cobra.Command{
 			return err
 		}
 
-		if err := c.DeleteTrainingDataset(args[0], fvVer, tdVer); err != nil {
+		tds, err := c.ListTrainingDatasets(args[0], fvVer, client.Page{})
+		if err != nil {
+			return err
+		}
+		var targets []client.TrainingDataset
+		for _, td := range tds {
+			if deleteAllVersions || td.Version == tdVer {
+				targets = append(targets, td)
+			}
+		}
+		if len(targets) == 0 {
+			if deleteAllVersions {
+				return fmt.Errorf("'%s' v%d has no training datasets", args[0], fvVer)
+			}
+			return fmt.Errorf("'%s' v%d has no training dataset v%d", args[0], fvVer, tdVer)
+		}
+
+		scan := newDependencyScan(c)
+		for _, td := range targets {
+			scan.target(artifactRef{Kind: "trainingDataset", Name: tdName(td, args[0], fvVer), Version: td.Version})
+		}
+		for _, td := range targets {
+			scan.trainingDataset(args[0], fvVer, td)
+		}
+		if ok, err := confirmDelete(scan); !ok {
 			return err
 		}
 
-		output.Success("Deleted training dataset v%d from '%s' v%d", tdVer, args[0], fvVer)
-		return nil
+		return deleteTargets(scan, func(i int) error {
+			return c.DeleteTrainingDataset(args[0], fvVer, targets[i].Version)
+		})
 	},
 }
 
@@ -147,12 +184,9 @@ Examples:
 			return fmt.Errorf("invalid version: %s", args[1])
 		}
 
-		if !output.JSONMode {
-			output.Info("Materializing training data from '%s' v%d...", args[0], fvVer)
-		}
-
 		script := buildTDComputeScript(args[0], fvVer, tdComputeFormat, tdComputeDesc, tdComputeSplit, tdComputeFilter, tdComputeStartTime, tdComputeEndTime)
-		if err := runPython(script); err != nil {
+		title := fmt.Sprintf("Materializing training data from '%s' v%d", args[0], fvVer)
+		if err := runPythonProgress(title, script); err != nil {
 			return fmt.Errorf("materialize training data: %w", err)
 		}
 		return nil
@@ -358,10 +392,11 @@ var tdReadCmd = &cobra.Command{
 
 Examples:
   hops td read my_view 1 --td-version 1
-  hops td read my_view 1 --td-version 1 --output train.parquet
-  hops td read my_view 1 --td-version 1 --split train --output train.csv
-  hops td read my_view 1 --td-version 1 --split test --output test.csv`,
-	Args: cobra.ExactArgs(2),
+  hops td read my_view 1 --td-version 1 --file train.parquet
+  hops td read my_view 1 --td-version 1 --split train --file train.csv
+  hops td read my_view 1 --td-version 1 --split test -o csv > test.csv`,
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{legacyOutputFlag: "file"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		fvVer, err := strconv.Atoi(args[1])
 		if err != nil {
@@ -375,15 +410,15 @@ Examples:
 			output.Info("Reading training data from '%s' v%d (TD v%d)...", args[0], fvVer, tdReadVersion)
 		}
 
-		script := buildTDReadScript(args[0], fvVer, tdReadVersion, tdReadOutput, tdReadSplit, output.JSONMode)
-		if err := runPython(script); err != nil {
+		script := buildTDReadScript(args[0], fvVer, tdReadVersion, tdReadOutput, tdReadSplit, output.Structured())
+		if err := runDataFrameScript(script, tdReadOutput); err != nil {
 			return fmt.Errorf("read training data: %w", err)
 		}
 		return nil
 	},
 }
 
-func buildTDReadScript(fvName string, fvVer, tdVer int, outputPath, split string, jsonMode bool) string {
+func buildTDReadScript(fvName string, fvVer, tdVer int, outputPath, split string, structured bool) string {
 	var sb strings.Builder
 	sb.WriteString(buildFVPreamble(fvName, fvVer))
 
@@ -425,10 +460,11 @@ print(f'Read {len(df)} rows, {len(df.columns)} columns', file=sys.stderr)
 			sb.WriteString(fmt.Sprintf("df.to_parquet(%q, index=False)\n", outputPath))
 		}
 		sb.WriteString(fmt.Sprintf("print('Saved to %s', file=sys.stderr)\n", outputPath))
-	} else if jsonMode {
-		sb.WriteString("print(df.to_json(orient='records', indent=2))\n")
+		if structured {
+			sb.WriteString(fmt.Sprintf("print(json.dumps({'path': %q, 'rows': len(df)}))\n", outputPath))
+		}
 	} else {
-		sb.WriteString("print(df.to_string(index=False))\n")
+		sb.WriteString(printDataFrame(structured))
 	}
 
 	return sb.String()
@@ -507,14 +543,14 @@ Examples:
 
 		if stats == nil {
 			if output.JSONMode {
-				output.PrintJSON(struct{}{})
+				output.PrintJSON(&client.Statistics{})
 				return nil
 			}
 			output.Info("No statistics computed for TD v%d. Use --compute to trigger.", tdStatsVersion)
 			return nil
 		}
 
-		if output.JSONMode {
+		if output.Structured() {
 			output.PrintJSON(stats)
 			return nil
 		}
@@ -532,9 +568,9 @@ Examples:
 		}
 
 		headers := []string{"FEATURE", "TYPE", "COUNT", "MEAN", "MIN", "MAX", "STDDEV", "NULLS", "COMPLETENESS"}
-		var rows [][]string
+		var rows []output.Row
 		for _, fs := range stats.FeatureDescriptiveStatistics {
-			rows = append(rows, []string{
+			rows = append(rows, output.Row{
 				fs.FeatureName,
 				fs.FeatureType,
 				fmtInt64(fs.Count),
@@ -635,13 +671,16 @@ func init() {
 	tdComputeCmd.Flags().StringVar(&tdComputeEndTime, "end-time", "", "End time filter (e.g. 2026-02-01)")
 
 	tdReadCmd.Flags().IntVar(&tdReadVersion, "td-version", 0, "Training dataset version (required)")
-	tdReadCmd.Flags().StringVar(&tdReadOutput, "output", "", "Save to file (.parquet, .csv, .json)")
+	tdReadCmd.Flags().StringVar(&tdReadOutput, "file", "", "Save to file (.parquet, .csv, .json)")
 	tdReadCmd.Flags().StringVar(&tdReadSplit, "split", "", "Read specific split (train, test)")
 
 	tdStatsCmd.Flags().IntVar(&tdStatsVersion, "td-version", 0, "Training dataset version (required)")
 	tdStatsCmd.Flags().StringVar(&tdStatsFeatures, "features", "", "Filter features (comma-separated)")
 	tdStatsCmd.Flags().BoolVar(&tdStatsCompute, "compute", false, "Trigger statistics computation")
 
+	addDeleteFlags(tdDeleteCmd)
+
+	addListFlags(tdListCmd)
 	tdCmd.AddCommand(tdListCmd)
 	tdCmd.AddCommand(tdCreateCmd)
 	tdCmd.AddCommand(tdDeleteCmd)
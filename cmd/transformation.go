@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"strconv"
 	"strings"
 
 	"github.com/MagicLex/hopsworks-cli/pkg/client"
@@ -19,6 +18,19 @@ var tfCmd = &cobra.Command{
 	Short:   "Manage transformation functions",
 }
 
+var tfListColumns = []output.Column[client.TransformationFunction]{
+	{Name: "NAME", Value: func(tf client.TransformationFunction) interface{} { return tf.HopsworksUdf.Name }},
+	{Name: "VERSION", Value: func(tf client.TransformationFunction) interface{} { return tf.Version }},
+	{Name: "INPUTS", Value: func(tf client.TransformationFunction) interface{} {
+		return strings.Join(tf.HopsworksUdf.TransformationFunctionArgumentNames, ", ")
+	}},
+	{Name: "OUTPUT", Value: func(tf client.TransformationFunction) interface{} {
+		return strings.Join(tf.HopsworksUdf.OutputTypes, ", ")
+	}},
+	{Name: "MODE", Value: func(tf client.TransformationFunction) interface{} { return tf.HopsworksUdf.ExecutionMode }},
+	{Name: "ID", Wide: true, Value: func(tf client.TransformationFunction) interface{} { return tf.ID }},
+}
+
 var tfListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List transformation functions",
@@ -28,31 +40,13 @@ var tfListCmd = &cobra.Command{
 			return err
 		}
 
-		tfs, err := c.ListTransformationFunctions()
+		tfs, err := c.ListTransformationFunctions(listPage())
 		if err != nil {
 			return err
 		}
+		tfs = trimPage(tfs)
 
-		if output.JSONMode {
-			output.PrintJSON(tfs)
-			return nil
-		}
-
-		headers := []string{"NAME", "VERSION", "INPUTS", "OUTPUT", "MODE"}
-		var rows [][]string
-		for _, tf := range tfs {
-			inputs := strings.Join(tf.HopsworksUdf.TransformationFunctionArgumentNames, ", ")
-			outputType := strings.Join(tf.HopsworksUdf.OutputTypes, ", ")
-			rows = append(rows, []string{
-				tf.HopsworksUdf.Name,
-				strconv.Itoa(tf.Version),
-				inputs,
-				outputType,
-				tf.HopsworksUdf.ExecutionMode,
-			})
-		}
-		output.Table(headers, rows)
-		return nil
+		return printList(tfs, tfListColumns)
 	},
 }
 
@@ -234,6 +228,7 @@ func init() {
 	tfCreateCmd.Flags().StringVar(&tfCreateCode, "code", "", "Inline Python code with @udf decorated function")
 	tfCreateCmd.Flags().IntVar(&tfCreateVersion, "version", 1, "Transformation function version")
 
+	addListFlags(tfListCmd)
 	tfCmd.AddCommand(tfListCmd)
 	tfCmd.AddCommand(tfCreateCmd)
 }
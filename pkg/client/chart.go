@@ -44,27 +44,27 @@ func (c *Client) dashboardsPath() string {
 
 // --- Charts ---
 
-func (c *Client) ListCharts() ([]Chart, error) {
-	data, err := c.Get(c.chartsPath())
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListCharts(page Page) ([]Chart, error) {
+	return listAll(Paginate(c, c.chartsPath(), page, parseCharts))
+}
 
+func parseCharts(data []byte) ([]Chart, int, error) {
 	var list struct {
 		Items []Chart `json:"items"`
+		Count int     `json:"count"`
 	}
 	if err := json.Unmarshal(data, &list); err == nil {
 		if list.Items != nil {
-			return list.Items, nil
+			return list.Items, list.Count, nil
 		}
-		return []Chart{}, nil
+		return []Chart{}, 0, nil
 	}
 
 	var charts []Chart
 	if err := json.Unmarshal(data, &charts); err != nil {
-		return nil, fmt.Errorf("parse charts: %w", err)
+		return nil, 0, fmt.Errorf("parse charts: %w", err)
 	}
-	return charts, nil
+	return charts, unpaged, nil
 }
 
 func (c *Client) GetChart(id int) (*Chart, error) {
@@ -123,27 +123,27 @@ func (c *Client) DeleteChart(id int) error {
 
 // --- Dashboards ---
 
-func (c *Client) ListDashboards() ([]Dashboard, error) {
-	data, err := c.Get(c.dashboardsPath())
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListDashboards(page Page) ([]Dashboard, error) {
+	return listAll(Paginate(c, c.dashboardsPath(), page, parseDashboards))
+}
 
+func parseDashboards(data []byte) ([]Dashboard, int, error) {
 	var list struct {
 		Items []Dashboard `json:"items"`
+		Count int         `json:"count"`
 	}
 	if err := json.Unmarshal(data, &list); err == nil {
 		if list.Items != nil {
-			return list.Items, nil
+			return list.Items, list.Count, nil
 		}
-		return []Dashboard{}, nil
+		return []Dashboard{}, 0, nil
 	}
 
 	var dashboards []Dashboard
 	if err := json.Unmarshal(data, &dashboards); err != nil {
-		return nil, fmt.Errorf("parse dashboards: %w", err)
+		return nil, 0, fmt.Errorf("parse dashboards: %w", err)
 	}
-	return dashboards, nil
+	return dashboards, unpaged, nil
 }
 
 func (c *Client) GetDashboard(id int) (*Dashboard, error) {
@@ -1,100 +1,273 @@
 package client
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
-	"encoding/json"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/MagicLex/hopsworks-cli/pkg/config"
 )
 
+const (
+	// DefaultTimeout is the per-request timeout when none is configured.
+	DefaultTimeout = 30 * time.Second
+	// DefaultRetries is the number of retries after the first attempt.
+	DefaultRetries = 3
+
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 15 * time.Second
+)
+
 type Client struct {
 	Config     *config.Config
 	HTTPClient *http.Client
+	Retries    int
+
+	ctx   context.Context
+	token *tokenState // nil outside internal mode
+	debug *debugLog   // nil unless --debug / --debug-dir
 }
 
 func New(cfg *config.Config) (*Client, error) {
+	if err := cfg.ResolveAPIKey(); err != nil {
+		return nil, err
+	}
 	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
 
-	// Skip TLS verification for internal cluster communication
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	tc, err := tlsConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tc
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	retries := cfg.Retries
+	if retries < 0 {
+		retries = 0
 	}
 
 	return &Client{
 		Config: cfg,
 		HTTPClient: &http.Client{
-			Timeout:   30 * time.Second,
+			Timeout:   timeout,
 			Transport: transport,
 		},
+		Retries: retries,
+		ctx:     context.Background(),
+		token:   newTokenState(cfg),
+		debug:   newDebugLog(cfg),
 	}, nil
 }
 
+// WithContext returns a shallow copy of the client whose requests are bound to ctx.
+// Cancelling ctx aborts in-flight requests and pending retries.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
+}
+
+// Context returns the context requests are bound to.
+func (c *Client) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
 func (c *Client) baseURL() string {
-	host := strings.TrimRight(c.Config.Host, "/")
+	return hostURL(c.Config.Host)
+}
+
+// hostURL returns host as a URL without a trailing slash; a bare host is https.
+func hostURL(host string) string {
+	host = strings.TrimRight(host, "/")
 	if !strings.HasPrefix(host, "http") {
 		host = "https://" + host
 	}
 	return host
 }
 
-func (c *Client) doRequest(method, path string, body io.Reader) ([]byte, error) {
-	url := c.baseURL() + path
-
-	req, err := http.NewRequest(method, url, body)
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
+func (c *Client) setAuth(req *http.Request) {
 	// Use JWT token if available (in-platform), otherwise API key
 	if c.Config.JWTToken != "" {
 		req.Header.Set("Authorization", "Bearer "+c.Config.JWTToken)
 	} else {
 		req.Header.Set("Authorization", "ApiKey "+c.Config.APIKey)
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+}
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+// send executes a request with retries and returns the final status code and body.
+// The body is buffered so it can be replayed on each attempt.
+func (c *Client) send(method, path, contentType string, body io.Reader) (int, []byte, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = io.ReadAll(body)
+		if err != nil {
+			return 0, nil, fmt.Errorf("read request body: %w", err)
+		}
 	}
-	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
-	}
+	ctx := c.Context()
+	url := c.baseURL() + path
 
-	if resp.StatusCode >= 400 {
-		// Try to extract error message from JSON response
-		var errResp struct {
-			ErrorMsg string `json:"errorMsg"`
-			UsrMsg   string `json:"usrMsg"`
-			DevMsg   string `json:"devMsg"`
+	reauthed := false
+	for attempt := 0; ; attempt++ {
+		c.reloadToken(false)
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+		if err != nil {
+			return 0, nil, fmt.Errorf("create request: %w", err)
 		}
-		if json.Unmarshal(data, &errResp) == nil {
-			msg := errResp.UsrMsg
-			if msg == "" {
-				msg = errResp.ErrorMsg
+		c.setAuth(req)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("Accept", "application/json")
+
+		start := time.Now()
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			c.debug.trace(req, payload, 0, nil, time.Since(start), err)
+			if ctx.Err() != nil {
+				return 0, nil, fmt.Errorf("request cancelled: %w", ctx.Err())
 			}
-			// Append devMsg when it has extra detail (e.g. Snowflake auth failures)
-			if msg != "" && errResp.DevMsg != "" && errResp.DevMsg != msg {
-				return nil, fmt.Errorf("API error (%d): %s — %s", resp.StatusCode, msg, errResp.DevMsg)
+			if attempt < c.Retries && isIdempotent(method) {
+				if werr := c.wait(attempt, 0); werr != nil {
+					return 0, nil, werr
+				}
+				continue
+			}
+			return 0, nil, fmt.Errorf("request failed: %w", withTLSHint(err))
+		}
+
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return 0, nil, fmt.Errorf("read response: %w", err)
+		}
+		c.debug.trace(req, payload, resp.StatusCode, data, time.Since(start), nil)
+
+		// In-pod JWTs rotate; pick up a new token.jwt and replay once.
+		// Nothing was processed on a 401, so this is safe for any verb.
+		if resp.StatusCode == http.StatusUnauthorized && c.token != nil {
+			if !reauthed && c.reloadToken(true) {
+				reauthed = true
+				attempt--
+				continue
 			}
-			if msg != "" {
-				return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, msg)
+			return 0, nil, c.unauthorized(resp.StatusCode, data)
+		}
+
+		if attempt < c.Retries && shouldRetryStatus(method, resp.StatusCode) {
+			if werr := c.wait(attempt, retryAfter(resp.Header.Get("Retry-After"))); werr != nil {
+				return 0, nil, werr
 			}
+			continue
+		}
+
+		return resp.StatusCode, data, nil
+	}
+}
+
+// wait sleeps before the next retry. A server-provided Retry-After wins over
+// exponential backoff; both are capped at retryMaxDelay.
+func (c *Client) wait(attempt int, after time.Duration) error {
+	delay := after
+	if delay <= 0 {
+		// Exponential backoff with full jitter
+		backoff := retryBaseDelay << attempt
+		if backoff > retryMaxDelay || backoff <= 0 {
+			backoff = retryMaxDelay
+		}
+		delay = time.Duration(rand.Int63n(int64(backoff)) + 1)
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-c.Context().Done():
+		return fmt.Errorf("request cancelled: %w", c.Context().Err())
+	}
+}
+
+// withTLSHint points at the TLS knobs when the server certificate can't be verified.
+func withTLSHint(err error) error {
+	var verr *tls.CertificateVerificationError
+	var uerr x509.UnknownAuthorityError
+	if errors.As(err, &verr) || errors.As(err, &uerr) {
+		return fmt.Errorf("%w (set ca_bundle in ~/.hops/config or pass --ca-cert; --insecure skips verification)", err)
+	}
+	return err
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// shouldRetryStatus reports whether a response status is transient.
+// Idempotent verbs retry on 429/502/503/504. POST only retries on 429 and 503,
+// where the server rejected the request before processing it.
+func shouldRetryStatus(method string, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+	return false
+}
+
+// retryAfter parses a Retry-After header (delay-seconds or HTTP-date).
+func retryAfter(v string) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
 		}
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(data))
 	}
+	return 0
+}
 
+func (c *Client) doRequest(method, path string, body io.Reader) ([]byte, error) {
+	status, data, err := c.send(method, path, "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+	if status >= 400 {
+		return nil, apiError(status, data)
+	}
 	return data, nil
 }
 
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Commit is one write to a time-travel (DELTA/HUDI) feature group.
+type Commit struct {
+	CommitID         int64  `json:"commitID"`
+	CommitDateString string `json:"commitDateString,omitempty"`
+	CommitTime       int64  `json:"commitTime"` // epoch millis
+	RowsInserted     int64  `json:"rowsInserted"`
+	RowsUpdated      int64  `json:"rowsUpdated"`
+	RowsDeleted      int64  `json:"rowsDeleted"`
+	ValidationID     *int   `json:"validationId,omitempty"`
+}
+
+type CommitList struct {
+	Items []Commit `json:"items"`
+	Count int      `json:"count"`
+}
+
+// GetFeatureGroupCommits lists a feature group's commits, newest first.
+func (c *Client) GetFeatureGroupCommits(fgID int, page Page) ([]Commit, error) {
+	path := fmt.Sprintf("%s/featuregroups/%d/commits?sort_by=committed_on:desc", c.FSPath(), fgID)
+	return listAll(Paginate(c, path, page, parseCommits))
+}
+
+func parseCommits(data []byte) ([]Commit, int, error) {
+	var list CommitList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, 0, fmt.Errorf("parse commits: %w", err)
+	}
+	return list.Items, list.Count, nil
+}
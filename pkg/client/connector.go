@@ -93,29 +93,29 @@ func (c *Client) connectorsPath() string {
 	return fmt.Sprintf("%s/storageconnectors", c.FSPath())
 }
 
-func (c *Client) ListStorageConnectors() ([]StorageConnector, error) {
-	data, err := c.Get(c.connectorsPath())
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListStorageConnectors(page Page) ([]StorageConnector, error) {
+	return listAll(Paginate(c, c.connectorsPath(), page, parseStorageConnectors))
+}
 
+func parseStorageConnectors(data []byte) ([]StorageConnector, int, error) {
 	// Try wrapped format first: {items: [...]}
 	var list struct {
 		Items []StorageConnector `json:"items"`
+		Count int                `json:"count"`
 	}
 	if err := json.Unmarshal(data, &list); err == nil {
 		if list.Items != nil {
-			return list.Items, nil
+			return list.Items, list.Count, nil
 		}
-		return []StorageConnector{}, nil
+		return []StorageConnector{}, 0, nil
 	}
 
 	// Try direct array
 	var connectors []StorageConnector
 	if err := json.Unmarshal(data, &connectors); err != nil {
-		return nil, fmt.Errorf("parse storage connectors: %w", err)
+		return nil, 0, fmt.Errorf("parse storage connectors: %w", err)
 	}
-	return connectors, nil
+	return connectors, unpaged, nil
 }
 
 func (c *Client) GetStorageConnector(name string) (*StorageConnector, error) {
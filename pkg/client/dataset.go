@@ -25,18 +25,17 @@ type datasetAPIList struct {
 	Count int              `json:"count"`
 }
 
-func (c *Client) ListDatasets(path string) ([]DatasetFile, error) {
+func (c *Client) ListDatasets(path string, page Page) ([]DatasetFile, error) {
 	path = strings.TrimPrefix(path, "/")
 
-	apiPath := fmt.Sprintf("%s/dataset/%s?action=listing&offset=0&limit=100", c.ProjectPath(), path)
-	data, err := c.Get(apiPath)
-	if err != nil {
-		return nil, err
-	}
+	apiPath := fmt.Sprintf("%s/dataset/%s?action=listing", c.ProjectPath(), path)
+	return listAll(Paginate(c, apiPath, page, parseDatasets))
+}
 
+func parseDatasets(data []byte) ([]DatasetFile, int, error) {
 	var dsList datasetAPIList
 	if err := json.Unmarshal(data, &dsList); err != nil {
-		return nil, fmt.Errorf("parse datasets: %w", err)
+		return nil, 0, fmt.Errorf("parse datasets: %w", err)
 	}
 
 	var files []DatasetFile
@@ -48,7 +47,7 @@ func (c *Client) ListDatasets(path string) ([]DatasetFile, error) {
 			Dir:         item.DatasetType == "DATASET",
 		})
 	}
-	return files, nil
+	return files, dsList.Count, nil
 }
 
 func (c *Client) MkDir(path string) error {
@@ -0,0 +1,236 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/config"
+)
+
+// debugBodyLimit caps how much of each body is echoed to stderr.
+const debugBodyLimit = 1024
+
+const redacted = "***"
+
+// sensitiveKeys are JSON fields whose values never reach the debug log or dumps
+// (compared lowercase, without '_' or '-').
+var sensitiveKeys = map[string]bool{
+	"password":           true,
+	"secretkey":          true,
+	"secret":             true,
+	"accesskey":          true,
+	"sessiontoken":       true,
+	"token":              true,
+	"apikey":             true,
+	"privatekey":         true,
+	"sasltoken":          true,
+	"clientsecret":       true,
+	"keystorepassword":   true,
+	"truststorepassword": true,
+}
+
+// Fallback for bodies that aren't valid JSON (e.g. Python snippets, form data)
+var sensitivePattern = regexp.MustCompile(`(?i)("?(?:password|secret_?key|access_?key|session_?token|token|api_?key|private_?key|client_?secret)"?\s*[:=]\s*)("[^"]*"|'[^']*'|[^\s,&}]+)`)
+
+// debugLog traces HTTP traffic to stderr for --debug, optionally writing the
+// full (redacted) bodies to a directory for bug reports. Each run dumps into
+// its own subdirectory, so reusing --debug-dir never overwrites earlier runs.
+type debugLog struct {
+	mu     sync.Mutex
+	dir    string
+	runDir string // created on the first dump
+	seq    int
+}
+
+// One log per directory for the process, so numbering runs on across clients
+var (
+	debugLogsMu sync.Mutex
+	debugLogs   = map[string]*debugLog{}
+)
+
+func newDebugLog(cfg *config.Config) *debugLog {
+	if !cfg.Debug && cfg.DebugDir == "" {
+		return nil
+	}
+	debugLogsMu.Lock()
+	defer debugLogsMu.Unlock()
+	if d, ok := debugLogs[cfg.DebugDir]; ok {
+		return d
+	}
+	d := &debugLog{dir: cfg.DebugDir}
+	debugLogs[cfg.DebugDir] = d
+	return d
+}
+
+// trace logs one attempt. status is 0 and err set when the request never got a response.
+func (d *debugLog) trace(req *http.Request, reqBody []byte, status int, respBody []byte, elapsed time.Duration, err error) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.seq++
+
+	reqBody = redactBody(reqBody)
+	respBody = redactBody(respBody)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[debug] %s %s -> error after %s: %v\n", req.Method, redactURL(req.URL), elapsed.Round(time.Millisecond), err)
+	} else {
+		fmt.Fprintf(os.Stderr, "[debug] %s %s -> %d (%s)\n", req.Method, redactURL(req.URL), status, elapsed.Round(time.Millisecond))
+	}
+	for _, name := range []string{"Authorization", "Content-Type"} {
+		if v := req.Header.Get(name); v != "" {
+			fmt.Fprintf(os.Stderr, "[debug]   %s: %s\n", name, redactHeader(name, v))
+		}
+	}
+	if len(reqBody) > 0 {
+		fmt.Fprintf(os.Stderr, "[debug]   > %s\n", truncateBody(reqBody))
+	}
+	if len(respBody) > 0 {
+		fmt.Fprintf(os.Stderr, "[debug]   < %s\n", truncateBody(respBody))
+	}
+
+	if d.dir != "" {
+		if err := d.dump(req, reqBody, status, respBody); err != nil {
+			fmt.Fprintf(os.Stderr, "[debug]   dump failed: %v\n", err)
+		}
+	}
+}
+
+// dump writes NNN-METHOD.request.txt / .response.txt into this run's
+// subdirectory of the debug directory (<time>-<pid>).
+func (d *debugLog) dump(req *http.Request, reqBody []byte, status int, respBody []byte) error {
+	if d.runDir == "" {
+		dir := filepath.Join(d.dir, fmt.Sprintf("%s-%d", time.Now().Format("20060102-150405"), os.Getpid()))
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+		d.runDir = dir
+		fmt.Fprintf(os.Stderr, "[debug] dumping to %s\n", dir)
+	}
+	prefix := filepath.Join(d.runDir, fmt.Sprintf("%03d-%s", d.seq, req.Method))
+
+	var rb bytes.Buffer
+	fmt.Fprintf(&rb, "%s %s\n", req.Method, redactURL(req.URL))
+	for name := range req.Header {
+		fmt.Fprintf(&rb, "%s: %s\n", name, redactHeader(name, req.Header.Get(name)))
+	}
+	rb.WriteString("\n")
+	rb.Write(reqBody)
+	if err := os.WriteFile(prefix+".request.txt", rb.Bytes(), 0600); err != nil {
+		return err
+	}
+
+	var sb bytes.Buffer
+	fmt.Fprintf(&sb, "HTTP %d\n\n", status)
+	sb.Write(respBody)
+	return os.WriteFile(prefix+".response.txt", sb.Bytes(), 0600)
+}
+
+func redactHeader(name, value string) string {
+	if !strings.EqualFold(name, "Authorization") {
+		return value
+	}
+	// Keep the scheme ("Bearer", "ApiKey") — it tells which auth path was used
+	if scheme, _, ok := strings.Cut(value, " "); ok {
+		return scheme + " " + redacted
+	}
+	return redacted
+}
+
+// redactURL masks sensitive query parameters and any password in the URL.
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	out := *u
+	if out.RawQuery != "" {
+		parts := strings.Split(out.RawQuery, "&")
+		for i, part := range parts {
+			key, _, _ := strings.Cut(part, "=")
+			if name, err := url.QueryUnescape(key); err == nil && isSensitiveKey(name) {
+				parts[i] = key + "=" + redacted
+			}
+		}
+		out.RawQuery = strings.Join(parts, "&")
+	}
+	return out.Redacted()
+}
+
+// redactBody masks sensitive fields, walking JSON when the body parses as JSON.
+func redactBody(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err == nil {
+		if redactValue(v) {
+			if out, err := json.Marshal(v); err == nil {
+				return out
+			}
+		}
+		return body
+	}
+	return sensitivePattern.ReplaceAll(body, []byte("${1}"+`"`+redacted+`"`))
+}
+
+// redactValue masks sensitive keys in place and reports whether anything changed.
+func redactValue(v interface{}) bool {
+	changed := false
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if isSensitiveKey(k) {
+				if s, ok := val.(string); !ok || s != "" {
+					t[k] = redacted
+					changed = true
+				}
+				continue
+			}
+			if redactValue(val) {
+				changed = true
+			}
+		}
+		// Hopsworks option lists: [{"name": "sfPassword", "value": "..."}]
+		if name, ok := t["name"].(string); ok && isSensitiveKey(name) {
+			if _, has := t["value"]; has {
+				t["value"] = redacted
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, item := range t {
+			if redactValue(item) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+func isSensitiveKey(k string) bool {
+	k = strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(k))
+	if sensitiveKeys[k] {
+		return true
+	}
+	// sfPassword, dbPassword, awsSecretKey, ...
+	return strings.HasSuffix(k, "password") || strings.HasSuffix(k, "secretkey") || strings.HasSuffix(k, "token")
+}
+
+func truncateBody(b []byte) string {
+	s := strings.TrimSpace(string(b))
+	if len(s) <= debugBodyLimit {
+		return s
+	}
+	return fmt.Sprintf("%s... (%d bytes)", s[:debugBodyLimit], len(s))
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/config"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"empty", ``, ``},
+		{"nothing sensitive", `{"name":"fg","version":1}`, `{"name":"fg","version":1}`},
+		{"top level", `{"password":"hunter2","user":"a"}`, `{"password":"***","user":"a"}`},
+		{"nested and suffixed", `{"conn":{"sfPassword":"x","aws_secret_key":"y","url":"u"}}`, `{"conn":{"aws_secret_key":"***","sfPassword":"***","url":"u"}}`},
+		{"in arrays", `[{"apiKey":"k"},{"ok":1}]`, `[{"apiKey":"***"},{"ok":1}]`},
+		{"empty value kept", `{"token":""}`, `{"token":""}`},
+		{"non-string value", `{"token":123}`, `{"token":"***"}`},
+		{"option list", `{"options":[{"name":"sfPassword","value":"x"},{"name":"sfUser","value":"me"}]}`, `{"options":[{"name":"sfPassword","value":"***"},{"name":"sfUser","value":"me"}]}`},
+		{"form data", `user=me&password=hunter2&x=1`, `user=me&password="***"&x=1`},
+		{"python snippet", `key = 'abc'; api_key = 'xyz'`, `key = 'abc'; api_key = "***"`},
+		{"not json, quoted", `{"secret_key": "a b", broken`, `{"secret_key": "***", broken`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(redactBody([]byte(tt.body))); got != tt.want {
+				t.Errorf("redactBody(%s)\n got %s\nwant %s", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactHeader(t *testing.T) {
+	tests := []struct{ name, value, want string }{
+		{"Authorization", "ApiKey abc.def", "ApiKey ***"},
+		{"authorization", "Bearer eyJ", "Bearer ***"},
+		{"Authorization", "opaque", "***"},
+		{"Content-Type", "application/json", "application/json"},
+	}
+	for _, tt := range tests {
+		if got := redactHeader(tt.name, tt.value); got != tt.want {
+			t.Errorf("redactHeader(%q, %q) = %q, want %q", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"https://h/api/project?offset=0&limit=10", "https://h/api/project?offset=0&limit=10"},
+		{"https://h/api/x?api_key=abc&limit=1", "https://h/api/x?api_key=***&limit=1"},
+		{"https://h/api/x?sfPassword=p%20q&token=t", "https://h/api/x?sfPassword=***&token=***"},
+		{"https://user:pw@h/api", "https://user:xxxxx@h/api"},
+		{"https://user@h/api", "https://user@h/api"},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := redactURL(u); got != tt.want {
+			t.Errorf("redactURL(%s) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDebugDumpPerRun(t *testing.T) {
+	dir := t.TempDir()
+	// A dump left by an earlier run
+	earlier := filepath.Join(dir, "001-GET.request.txt")
+	os.WriteFile(earlier, []byte("earlier run"), 0600)
+
+	d := newDebugLog(&config.Config{DebugDir: dir})
+	if again := newDebugLog(&config.Config{DebugDir: dir}); again != d {
+		t.Error("clients of one process should share the debug log")
+	}
+	req, _ := http.NewRequest("GET", "https://h/api?token=secret", nil)
+	req.Header.Set("Authorization", "ApiKey k")
+	d.seq = 1
+	if err := d.dump(req, nil, 200, []byte(`{"ok":true}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, _ := os.ReadFile(earlier); string(data) != "earlier run" {
+		t.Errorf("earlier dump overwritten: %q", data)
+	}
+	if filepath.Dir(d.runDir) != dir || !strings.HasSuffix(d.runDir, "-"+strconv.Itoa(os.Getpid())) {
+		t.Errorf("run directory %s, want <time>-<pid> in %s", d.runDir, dir)
+	}
+	data, err := os.ReadFile(filepath.Join(d.runDir, "001-GET.request.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(data); strings.Contains(s, "secret") || strings.Contains(s, "ApiKey k") {
+		t.Errorf("dump leaks secrets:\n%s", s)
+	}
+	if _, err := os.Stat(filepath.Join(d.runDir, "001-GET.response.txt")); err != nil {
+		t.Error(err)
+	}
+}
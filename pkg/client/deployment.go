@@ -4,8 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 )
 
 type Deployment struct {
@@ -61,17 +59,16 @@ func (c *Client) ServingPath() string {
 	return fmt.Sprintf("%s/serving", c.ProjectPath())
 }
 
-func (c *Client) ListDeployments() ([]Deployment, error) {
-	data, err := c.Get(c.ServingPath())
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListDeployments(page Page) ([]Deployment, error) {
+	return listAll(Paginate(c, c.ServingPath(), page, parseDeployments))
+}
 
+func parseDeployments(data []byte) ([]Deployment, int, error) {
 	var deployments []Deployment
 	if err := json.Unmarshal(data, &deployments); err != nil {
-		return nil, fmt.Errorf("parse deployments: %w", err)
+		return nil, 0, fmt.Errorf("parse deployments: %w", err)
 	}
-	return deployments, nil
+	return deployments, unpaged, nil
 }
 
 func (c *Client) GetDeployment(id int) (*Deployment, error) {
@@ -88,7 +85,7 @@ func (c *Client) GetDeployment(id int) (*Deployment, error) {
 }
 
 func (c *Client) GetDeploymentByName(name string) (*Deployment, error) {
-	deployments, err := c.ListDeployments()
+	deployments, err := c.ListDeployments(Page{})
 	if err != nil {
 		return nil, err
 	}
@@ -148,33 +145,14 @@ func (c *Client) Predict(modelName string, payload json.RawMessage) (json.RawMes
 		bodyBytes, _ = json.Marshal(map[string]json.RawMessage{"instances": payload})
 	}
 
-	// Use doRequest directly to get raw response
-	url := c.baseURL() + path
-	req, err := http.NewRequest("POST", url, bytes.NewReader(bodyBytes))
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-	if c.Config.JWTToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.Config.JWTToken)
-	} else {
-		req.Header.Set("Authorization", "ApiKey "+c.Config.APIKey)
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.HTTPClient.Do(req)
+	// Bypass doRequest to get the raw response and keep the predictor's error body
+	status, data, err := c.send("POST", path, "application/json", bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, fmt.Errorf("predict request failed: %w", err)
 	}
-	defer resp.Body.Close()
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read predict response: %w", err)
-	}
 
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("predict error (%d): %s", resp.StatusCode, string(data))
+	if status >= 400 {
+		return nil, fmt.Errorf("predict: %w", apiError(status, data))
 	}
 
 	return data, nil
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned for any Hopsworks REST response with status >= 400.
+// It carries the backend's RESTCodes errorCode and user/dev messages so callers
+// can branch on the failure kind instead of parsing strings.
+type APIError struct {
+	StatusCode int    `json:"status"`
+	ErrorCode  int    `json:"errorCode,omitempty"`
+	UsrMsg     string `json:"usrMsg,omitempty"`
+	DevMsg     string `json:"devMsg,omitempty"`
+	ErrorMsg   string `json:"errorMsg,omitempty"`
+	Body       string `json:"-"` // Raw body when the response isn't a Hopsworks error JSON
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message()
+	// Append devMsg when it has extra detail (e.g. Snowflake auth failures)
+	if msg != "" && e.DevMsg != "" && e.DevMsg != msg {
+		return fmt.Sprintf("API error (%d): %s — %s", e.StatusCode, msg, e.DevMsg)
+	}
+	if msg != "" {
+		return fmt.Sprintf("API error (%d): %s", e.StatusCode, msg)
+	}
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Body)
+}
+
+// Message returns the most user-facing message available.
+func (e *APIError) Message() string {
+	if e.UsrMsg != "" {
+		return e.UsrMsg
+	}
+	return e.ErrorMsg
+}
+
+// apiError builds an *APIError from a Hopsworks error response body.
+func apiError(status int, data []byte) error {
+	e := &APIError{StatusCode: status}
+	if json.Unmarshal(data, e) != nil || e.Message() == "" {
+		e.Body = string(data)
+	}
+	// Unmarshal may have overwritten the status with a body field of the same name
+	e.StatusCode = status
+	return e
+}
+
+// AsAPIError unwraps err to an *APIError, if there is one in the chain.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+func hasStatus(err error, codes ...int) bool {
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return false
+	}
+	for _, code := range codes {
+		if apiErr.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether err is a 404 from the API.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is a 409 from the API (resource already exists).
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsUnauthorized reports whether err is a 401 or 403 from the API.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized, http.StatusForbidden)
+}
+
+// IsBadRequest reports whether the API rejected the request as invalid (400/422).
+func IsBadRequest(err error) bool {
+	return hasStatus(err, http.StatusBadRequest, http.StatusUnprocessableEntity)
+}
+
+// IsServerError reports whether err is a 5xx from the API.
+func IsServerError(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode >= 500
+}
@@ -43,6 +43,7 @@ type FeatureGroup struct {
 	EmbeddingIndex   *EmbeddingIndex `json:"embeddingIndex,omitempty"`
 	StorageConnector *StorageConnector `json:"storageConnector,omitempty"`
 	DataSource       *DataSource       `json:"dataSource,omitempty"`
+	StatisticsConfig *StatisticsConfig `json:"statisticsConfig,omitempty"`
 }
 
 // FGTypeLabel returns a human-readable type label from the DTO type discriminator.
@@ -64,27 +65,26 @@ type FeatureGroupList struct {
 	Count int            `json:"count"`
 }
 
-func (c *Client) ListFeatureGroups() ([]FeatureGroup, error) {
-	data, err := c.Get(fmt.Sprintf("%s/featuregroups", c.FSPath()))
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListFeatureGroups(page Page) ([]FeatureGroup, error) {
+	return listAll(Paginate(c, fmt.Sprintf("%s/featuregroups", c.FSPath()), page, parseFeatureGroups))
+}
 
+func parseFeatureGroups(data []byte) ([]FeatureGroup, int, error) {
 	// The API might return a wrapped object {items:[...]}, an empty object {}, or a direct array
 	var fgList FeatureGroupList
 	if err := json.Unmarshal(data, &fgList); err == nil {
 		if fgList.Items != nil {
-			return fgList.Items, nil
+			return fgList.Items, fgList.Count, nil
 		}
-		return []FeatureGroup{}, nil
+		return []FeatureGroup{}, 0, nil
 	}
 
 	// Try as direct array
 	var fgs []FeatureGroup
 	if err := json.Unmarshal(data, &fgs); err != nil {
-		return nil, fmt.Errorf("parse feature groups: %w", err)
+		return nil, 0, fmt.Errorf("parse feature groups: %w", err)
 	}
-	return fgs, nil
+	return fgs, unpaged, nil
 }
 
 func (c *Client) GetFeatureGroup(name string, version int) (*FeatureGroup, error) {
@@ -139,8 +139,33 @@ func (c *Client) GetFeatureGroup(name string, version int) (*FeatureGroup, error
 	return &fg, nil
 }
 
-func (c *Client) PreviewFeatureGroup(fgID int, n int) ([]map[string]interface{}, error) {
-	path := fmt.Sprintf("%s/featuregroups/%d/preview?storage=offline&limit=%d", c.FSPath(), fgID, n)
+// ListFeatureGroupVersions returns every version of the named feature group.
+func (c *Client) ListFeatureGroupVersions(name string) ([]FeatureGroup, error) {
+	data, err := c.Get(fmt.Sprintf("%s/featuregroups/%s", c.FSPath(), name))
+	if err != nil {
+		return nil, err
+	}
+	var fgs []FeatureGroup
+	if err := json.Unmarshal(data, &fgs); err == nil {
+		return fgs, nil
+	}
+	var fgList FeatureGroupList
+	if err := json.Unmarshal(data, &fgList); err == nil && fgList.Items != nil {
+		return fgList.Items, nil
+	}
+	var fg FeatureGroup
+	if err := json.Unmarshal(data, &fg); err != nil {
+		return nil, fmt.Errorf("parse feature group: %w", err)
+	}
+	return []FeatureGroup{fg}, nil
+}
+
+// PreviewFeatureGroup returns up to n rows from the offline or online store.
+func (c *Client) PreviewFeatureGroup(fgID int, n int, storage string) ([]map[string]interface{}, error) {
+	if storage == "" {
+		storage = "offline"
+	}
+	path := fmt.Sprintf("%s/featuregroups/%d/preview?storage=%s&limit=%d", c.FSPath(), fgID, storage, n)
 
 	data, err := c.Get(path)
 	if err != nil {
@@ -185,8 +210,9 @@ type CreateFeatureGroupRequest struct {
 	EmbeddingIndex   *EmbeddingIndex `json:"embeddingIndex,omitempty"`
 }
 
-func (c *Client) CreateFeatureGroup(req *CreateFeatureGroupRequest) (*FeatureGroup, error) {
-	// Set required fields from client config
+// ApplyDefaults sets the fields CreateFeatureGroup fills in before posting:
+// the DTO type (stream when online, cached otherwise) and the feature store.
+func (req *CreateFeatureGroupRequest) ApplyDefaults(featureStoreID int) {
 	if req.Type == "" {
 		if req.OnlineEnabled {
 			req.Type = "streamFeatureGroupDTO"
@@ -194,7 +220,12 @@ func (c *Client) CreateFeatureGroup(req *CreateFeatureGroupRequest) (*FeatureGro
 			req.Type = "cachedFeaturegroupDTO"
 		}
 	}
-	req.FeatureStoreID = c.Config.FeatureStoreID
+	req.FeatureStoreID = featureStoreID
+}
+
+func (c *Client) CreateFeatureGroup(req *CreateFeatureGroupRequest) (*FeatureGroup, error) {
+	// Set required fields from client config
+	req.ApplyDefaults(c.Config.FeatureStoreID)
 
 	body, err := json.Marshal(req)
 	if err != nil {
@@ -214,6 +245,29 @@ func (c *Client) CreateFeatureGroup(req *CreateFeatureGroupRequest) (*FeatureGro
 	return &fg, nil
 }
 
+// UpdateFeatureGroup PUTs fg to the feature group endpoint. action is the
+// backend flag naming what to change: "updateMetadata" (description, appended
+// features, feature descriptions), "updateStatsConfig", "enableOnline" or
+// "disableOnline".
+func (c *Client) UpdateFeatureGroup(fg *FeatureGroup, action string) (*FeatureGroup, error) {
+	body, err := json.Marshal(fg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	path := fmt.Sprintf("%s/featuregroups/%d?%s=true", c.FSPath(), fg.ID, action)
+	data, err := c.Put(path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var updated FeatureGroup
+	if err := json.Unmarshal(data, &updated); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	return &updated, nil
+}
+
 func (c *Client) DeleteFeatureGroup(fgID int) error {
 	_, err := c.Delete(fmt.Sprintf("%s/featuregroups/%d", c.FSPath(), fgID))
 	return err
@@ -11,15 +11,15 @@ type FeatureStore struct {
 	ProjectID        int    `json:"projectId"`
 }
 
-func (c *Client) ListFeatureStores() ([]FeatureStore, error) {
-	data, err := c.Get(fmt.Sprintf("/hopsworks-api/api/project/%d/featurestores", c.Config.ProjectID))
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListFeatureStores(page Page) ([]FeatureStore, error) {
+	path := fmt.Sprintf("/hopsworks-api/api/project/%d/featurestores", c.Config.ProjectID)
+	return listAll(Paginate(c, path, page, parseFeatureStores))
+}
 
+func parseFeatureStores(data []byte) ([]FeatureStore, int, error) {
 	var stores []FeatureStore
 	if err := json.Unmarshal(data, &stores); err != nil {
-		return nil, fmt.Errorf("parse feature stores: %w", err)
+		return nil, 0, fmt.Errorf("parse feature stores: %w", err)
 	}
-	return stores, nil
+	return stores, unpaged, nil
 }
@@ -21,28 +21,27 @@ type FeatureViewList struct {
 	Count int           `json:"count"`
 }
 
-func (c *Client) ListFeatureViews() ([]FeatureView, error) {
-	data, err := c.Get(fmt.Sprintf("%s/featureview", c.FSPath()))
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListFeatureViews(page Page) ([]FeatureView, error) {
+	return listAll(Paginate(c, fmt.Sprintf("%s/featureview", c.FSPath()), page, parseFeatureViews))
+}
 
+func parseFeatureViews(data []byte) ([]FeatureView, int, error) {
 	var fvList FeatureViewList
 	if err := json.Unmarshal(data, &fvList); err == nil {
 		if fvList.Items != nil {
-			return fvList.Items, nil
+			return fvList.Items, fvList.Count, nil
 		}
 		// count: 0 with no items means empty
 		if fvList.Count == 0 {
-			return []FeatureView{}, nil
+			return []FeatureView{}, 0, nil
 		}
 	}
 
 	var fvs []FeatureView
 	if err := json.Unmarshal(data, &fvs); err != nil {
-		return nil, fmt.Errorf("parse feature views: %w", err)
+		return nil, 0, fmt.Errorf("parse feature views: %w", err)
 	}
-	return fvs, nil
+	return fvs, unpaged, nil
 }
 
 func (c *Client) GetFeatureView(name string, version int) (*FeatureView, error) {
@@ -385,6 +384,20 @@ func parseQueryJoins(query map[string]interface{}) []FVQueryJoin {
 	return result
 }
 
+// ListFeatureViewVersions returns every version of the named feature view.
+func (c *Client) ListFeatureViewVersions(name string) ([]FeatureView, error) {
+	data, err := c.Get(fmt.Sprintf("%s/featureview/%s", c.FSPath(), name))
+	if err != nil {
+		return nil, err
+	}
+	var fv FeatureView
+	if err := json.Unmarshal(data, &fv); err == nil && fv.Name != "" {
+		return []FeatureView{fv}, nil
+	}
+	fvs, _, err := parseFeatureViews(data)
+	return fvs, err
+}
+
 func (c *Client) DeleteFeatureView(name string, version int) error {
 	var path string
 	if version > 0 {
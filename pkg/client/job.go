@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"strings"
 )
 
@@ -69,27 +68,26 @@ type JobSchedule struct {
 	NextExecutionDateTime *int64 `json:"nextExecutionDateTime,omitempty"`
 }
 
-func (c *Client) ListJobs() ([]Job, error) {
-	data, err := c.Get(fmt.Sprintf("%s/jobs", c.ProjectPath()))
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListJobs(page Page) ([]Job, error) {
+	return listAll(Paginate(c, fmt.Sprintf("%s/jobs", c.ProjectPath()), page, parseJobs))
+}
 
+func parseJobs(data []byte) ([]Job, int, error) {
 	var jobList JobList
 	if err := json.Unmarshal(data, &jobList); err == nil {
 		if jobList.Items != nil {
-			return jobList.Items, nil
+			return jobList.Items, jobList.Count, nil
 		}
 		if jobList.Count == 0 {
-			return []Job{}, nil
+			return []Job{}, 0, nil
 		}
 	}
 
 	var jobs []Job
 	if err := json.Unmarshal(data, &jobs); err != nil {
-		return nil, fmt.Errorf("parse jobs: %w", err)
+		return nil, 0, fmt.Errorf("parse jobs: %w", err)
 	}
-	return jobs, nil
+	return jobs, unpaged, nil
 }
 
 func (c *Client) GetJob(name string) (*Job, error) {
@@ -200,20 +198,18 @@ func (c *Client) StopExecution(jobName string, execID int) (*Execution, error) {
 	return &exec, nil
 }
 
-func (c *Client) GetExecutions(jobName string, limit int) ([]Execution, error) {
-	if limit <= 0 {
-		limit = 10
-	}
-	path := fmt.Sprintf("%s/jobs/%s/executions?sort_by=submissionTime:desc&limit=%d", c.ProjectPath(), jobName, limit)
-	data, err := c.Get(path)
-	if err != nil {
-		return nil, err
-	}
+// GetExecutions lists a job's executions, newest first.
+func (c *Client) GetExecutions(jobName string, page Page) ([]Execution, error) {
+	path := fmt.Sprintf("%s/jobs/%s/executions?sort_by=submissionTime:desc", c.ProjectPath(), jobName)
+	return listAll(Paginate(c, path, page, parseExecutions))
+}
+
+func parseExecutions(data []byte) ([]Execution, int, error) {
 	var list ExecutionList
 	if err := json.Unmarshal(data, &list); err != nil {
-		return nil, fmt.Errorf("parse executions: %w", err)
+		return nil, 0, fmt.Errorf("parse executions: %w", err)
 	}
-	return list.Items, nil
+	return list.Items, list.Count, nil
 }
 
 func (c *Client) GetExecutionLogs(jobName string, execID int, logType string) (*ExecutionLog, error) {
@@ -281,55 +277,12 @@ func IsExecutionTerminal(state string) bool {
 
 // postText sends a POST with text/plain content type (used for job execution args).
 func (c *Client) postText(path string, body io.Reader) ([]byte, error) {
-	url := c.baseURL() + path
-	if body == nil {
-		body = strings.NewReader("")
-	}
-
-	req, err := http.NewRequest("POST", url, body)
+	status, data, err := c.send("POST", path, "text/plain", body)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
-	if c.Config.JWTToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.Config.JWTToken)
-	} else {
-		req.Header.Set("Authorization", "ApiKey "+c.Config.APIKey)
-	}
-	req.Header.Set("Content-Type", "text/plain")
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
+		return nil, err
 	}
-
-	if resp.StatusCode >= 400 {
-		var errResp struct {
-			ErrorMsg string `json:"errorMsg"`
-			UsrMsg   string `json:"usrMsg"`
-			DevMsg   string `json:"devMsg"`
-		}
-		if json.Unmarshal(data, &errResp) == nil {
-			msg := errResp.UsrMsg
-			if msg == "" {
-				msg = errResp.ErrorMsg
-			}
-			if msg != "" && errResp.DevMsg != "" && errResp.DevMsg != msg {
-				return nil, fmt.Errorf("API error (%d): %s — %s", resp.StatusCode, msg, errResp.DevMsg)
-			}
-			if msg != "" {
-				return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, msg)
-			}
-		}
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(data))
+	if status >= 400 {
+		return nil, apiError(status, data)
 	}
-
 	return data, nil
 }
@@ -28,20 +28,19 @@ func (c *Client) MRPath() string {
 	return fmt.Sprintf("%s/modelregistries/%d/models", c.ProjectPath(), c.Config.ProjectID)
 }
 
-func (c *Client) ListModels() ([]Model, error) {
-	data, err := c.Get(c.MRPath())
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListModels(page Page) ([]Model, error) {
+	return listAll(Paginate(c, c.MRPath(), page, parseModels))
+}
 
+func parseModels(data []byte) ([]Model, int, error) {
 	var list ModelList
 	if err := json.Unmarshal(data, &list); err != nil {
-		return nil, fmt.Errorf("parse models: %w", err)
+		return nil, 0, fmt.Errorf("parse models: %w", err)
 	}
 	if list.Items == nil {
-		return []Model{}, nil
+		return []Model{}, 0, nil
 	}
-	return list.Items, nil
+	return list.Items, list.Count, nil
 }
 
 func (c *Client) GetModel(name string, version int) (*Model, error) {
@@ -68,7 +67,7 @@ func (c *Client) GetModel(name string, version int) (*Model, error) {
 }
 
 func (c *Client) getLatestModel(name string) (*Model, error) {
-	models, err := c.ListModels()
+	models, err := c.ListModels(Page{})
 	if err != nil {
 		return nil, err
 	}
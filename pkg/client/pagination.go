@@ -0,0 +1,141 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// PageSize is how many items are requested per call when following pages.
+const PageSize = 100
+
+// Page selects a window of a list. The zero value means every item.
+type Page struct {
+	Offset int
+	Limit  int // 0 = no limit
+}
+
+// unpaged is the count a parse func reports when the endpoint returned a bare
+// array, i.e. it doesn't paginate and the response is the whole list.
+const unpaged = -1
+
+// parseFunc decodes one list response into its items and the server-side total.
+type parseFunc[T any] func(data []byte) (items []T, count int, err error)
+
+// Paginate iterates over a list endpoint, following offset/limit until the
+// server's count is reached, a short page comes back or page.Limit is hit.
+// Endpoints that ignore paging and return everything are windowed locally.
+func Paginate[T any](c *Client, path string, page Page, parse parseFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		offset := max(page.Offset, 0)
+		remaining := page.Limit
+		var prev []byte
+
+		for first := true; ; first = false {
+			size := PageSize
+			if page.Limit > 0 && remaining < size {
+				size = remaining
+			}
+
+			data, err := c.Get(withPaging(path, offset, size))
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			// The same page again: the server ignores offset and we have it all
+			if !first && bytes.Equal(data, prev) {
+				return
+			}
+			prev = data
+			items, count, err := parse(data)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			if first && len(items) > 0 {
+				ignored, err := ignoresPaging(c, path, offset, size, data, len(items), count)
+				if err != nil {
+					yield(zero, err)
+					return
+				}
+				if ignored {
+					for _, item := range window(items, page) {
+						if !yield(item, nil) {
+							return
+						}
+					}
+					return
+				}
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			offset += len(items)
+			remaining -= len(items)
+			if len(items) < size || (count > 0 && offset >= count) || (page.Limit > 0 && remaining <= 0) {
+				return
+			}
+		}
+	}
+}
+
+// ignoresPaging reports whether the first response (n items, count as
+// parsed) is the whole list rather than the requested page: more items than
+// asked for, or all count items despite an offset. Without a count, a short
+// response at an offset could be either; it is the whole list when the same
+// request at offset 0 returns the same bytes.
+func ignoresPaging(c *Client, path string, offset, size int, data []byte, n, count int) (bool, error) {
+	switch {
+	case n > size:
+		return true, nil
+	case count > 0:
+		return offset > 0 && n == count, nil
+	case offset == 0:
+		return false, nil
+	}
+	start, err := c.Get(withPaging(path, 0, size))
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(start, data), nil
+}
+
+// listAll drains a Paginate iterator into a slice (never nil).
+func listAll[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	out := []T{}
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+// window applies page to a complete list.
+func window[T any](items []T, page Page) []T {
+	if page.Offset >= len(items) {
+		return nil
+	}
+	if page.Offset > 0 {
+		items = items[page.Offset:]
+	}
+	if page.Limit > 0 && page.Limit < len(items) {
+		items = items[:page.Limit]
+	}
+	return items
+}
+
+func withPaging(path string, offset, limit int) string {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("%s%soffset=%d&limit=%d", path, sep, offset, limit)
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/config"
+)
+
+// listServer serves total items as a list endpoint that either pages with a
+// count, pages with a bare array (no count) or ignores paging altogether.
+func listServer(t *testing.T, total int, mode string) *Client {
+	t.Helper()
+	all := make([]int, total)
+	for i := range all {
+		all[i] = i
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		lo := min(offset, total)
+		hi := min(lo+limit, total)
+		switch mode {
+		case "count":
+			json.NewEncoder(w).Encode(map[string]interface{}{"items": all[lo:hi], "count": total})
+		case "nocount":
+			json.NewEncoder(w).Encode(all[lo:hi])
+		default:
+			json.NewEncoder(w).Encode(all)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(&config.Config{Host: srv.URL, APIKey: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func parseInts(data []byte) ([]int, int, error) {
+	var env struct {
+		Items []int `json:"items"`
+		Count int   `json:"count"`
+	}
+	if err := json.Unmarshal(data, &env); err == nil {
+		return env.Items, env.Count, nil
+	}
+	var items []int
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, 0, err
+	}
+	return items, unpaged, nil
+}
+
+func TestPaginate(t *testing.T) {
+	pages := []Page{{}, {Offset: 3}, {Limit: 7}, {Offset: 3, Limit: 7}, {Offset: 120}, {Offset: 95, Limit: 10}, {Offset: 300}}
+	for _, mode := range []string{"count", "nocount", "ignore"} {
+		for _, total := range []int{0, 5, PageSize, 250} {
+			for _, page := range pages {
+				t.Run(fmt.Sprintf("%s/%d/%+v", mode, total, page), func(t *testing.T) {
+					got, err := listAll(Paginate(listServer(t, total, mode), "/list", page, parseInts))
+					if err != nil {
+						t.Fatal(err)
+					}
+					want := []int{}
+					for i := page.Offset; i < total && (page.Limit == 0 || len(want) < page.Limit); i++ {
+						want = append(want, i)
+					}
+					if !reflect.DeepEqual(got, want) {
+						t.Errorf("got %d items %v, want %d items %v", len(got), head(got), len(want), head(want))
+					}
+				})
+			}
+		}
+	}
+}
+
+func head(items []int) []int {
+	return items[:min(len(items), 5)]
+}
+
+func TestWindow(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4}
+	tests := []struct {
+		page Page
+		want []int
+	}{
+		{Page{}, []int{0, 1, 2, 3, 4}},
+		{Page{Offset: 2}, []int{2, 3, 4}},
+		{Page{Limit: 2}, []int{0, 1}},
+		{Page{Offset: 1, Limit: 3}, []int{1, 2, 3}},
+		{Page{Offset: 4, Limit: 3}, []int{4}},
+		{Page{Offset: 5}, nil},
+		{Page{Offset: 9, Limit: 1}, nil},
+	}
+	for _, tt := range tests {
+		if got := window(items, tt.page); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("window(%+v) = %v, want %v", tt.page, got, tt.want)
+		}
+	}
+}
@@ -22,12 +22,11 @@ type projectWrapper struct {
 	} `json:"project"`
 }
 
-func (c *Client) ListProjects() ([]Project, error) {
-	data, err := c.Get("/hopsworks-api/api/project")
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListProjects(page Page) ([]Project, error) {
+	return listAll(Paginate(c, "/hopsworks-api/api/project", page, parseProjects))
+}
 
+func parseProjects(data []byte) ([]Project, int, error) {
 	// Try nested format first: [{"project": {"id": ..., "name": ...}}]
 	var wrappers []projectWrapper
 	if err := json.Unmarshal(data, &wrappers); err == nil && len(wrappers) > 0 && wrappers[0].Project.ID > 0 {
@@ -40,15 +39,15 @@ func (c *Client) ListProjects() ([]Project, error) {
 				Created:     w.Project.Created,
 			})
 		}
-		return projects, nil
+		return projects, unpaged, nil
 	}
 
 	// Fallback: flat format
 	var projects []Project
 	if err := json.Unmarshal(data, &projects); err != nil {
-		return nil, fmt.Errorf("parse projects: %w", err)
+		return nil, 0, fmt.Errorf("parse projects: %w", err)
 	}
-	return projects, nil
+	return projects, unpaged, nil
 }
 
 func (c *Client) GetProject(id int) (*Project, error) {
@@ -76,7 +75,7 @@ func (c *Client) GetProject(id int) (*Project, error) {
 }
 
 func (c *Client) GetProjectByName(name string) (*Project, error) {
-	projects, err := c.ListProjects()
+	projects, err := c.ListProjects(Page{})
 	if err != nil {
 		return nil, err
 	}
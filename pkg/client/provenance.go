@@ -0,0 +1,75 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Provenance artifact types as the backend reports them.
+const (
+	ArtifactFeatureGroup    = "FEATURE_GROUP"
+	ArtifactFeatureView     = "FEATURE_VIEW"
+	ArtifactTrainingDataset = "TRAINING_DATASET"
+	ArtifactModel           = "MODEL"
+)
+
+// ProvenanceLink is one node of the explicit provenance graph with the links
+// around it, as many levels up and down as were requested.
+type ProvenanceLink struct {
+	Node       ProvenanceNode   `json:"node"`
+	Upstream   []ProvenanceLink `json:"upstream,omitempty"`
+	Downstream []ProvenanceLink `json:"downstream,omitempty"`
+}
+
+type ProvenanceNode struct {
+	ArtifactType   string             `json:"artifact_type"`
+	Accessible     bool               `json:"accessible"`
+	Deleted        bool               `json:"deleted"`
+	ExceptionCause string             `json:"exception_cause,omitempty"`
+	Artifact       ProvenanceArtifact `json:"artifact"`
+}
+
+// ProvenanceArtifact holds the fields shared by every artifact type; model
+// IDs are strings, so the ID is left out.
+type ProvenanceArtifact struct {
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+}
+
+// GetFeatureGroupProvenance returns the provenance graph around a feature
+// group: its parents up to upstream levels and its dependents down to
+// downstream levels (feature views, derived feature groups).
+func (c *Client) GetFeatureGroupProvenance(fgID, upstream, downstream int) (*ProvenanceLink, error) {
+	path := fmt.Sprintf("%s/featuregroups/%d/provenance/links", c.FSPath(), fgID)
+	return c.getProvenance(path, upstream, downstream)
+}
+
+// GetFeatureViewProvenance returns the provenance graph around a feature view,
+// or around one of its training datasets when tdVersion > 0 (models).
+func (c *Client) GetFeatureViewProvenance(name string, version, tdVersion, upstream, downstream int) (*ProvenanceLink, error) {
+	path := fmt.Sprintf("%s/featureview/%s/version/%d", c.FSPath(), name, version)
+	if tdVersion > 0 {
+		path += fmt.Sprintf("/trainingdatasets/version/%d", tdVersion)
+	}
+	return c.getProvenance(path+"/provenance/links", upstream, downstream)
+}
+
+// GetModelProvenance returns the provenance graph around a model version:
+// the feature view and training dataset it was trained on.
+func (c *Client) GetModelProvenance(name string, version, upstream, downstream int) (*ProvenanceLink, error) {
+	path := fmt.Sprintf("%s/%s_%d/provenance/links", c.MRPath(), name, version)
+	return c.getProvenance(path, upstream, downstream)
+}
+
+func (c *Client) getProvenance(path string, upstream, downstream int) (*ProvenanceLink, error) {
+	path += fmt.Sprintf("?expand=provenance_artifacts&upstreamLvls=%d&downstreamLvls=%d", upstream, downstream)
+	data, err := c.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	var link ProvenanceLink
+	if err := json.Unmarshal(data, &link); err != nil {
+		return nil, fmt.Errorf("parse provenance: %w", err)
+	}
+	return &link, nil
+}
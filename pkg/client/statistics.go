@@ -35,6 +35,15 @@ type Statistics struct {
 	WindowEndCommitTime         *int64              `json:"windowEndCommitTime,omitempty"`
 }
 
+// StatisticsConfig selects which statistics are computed when data is written.
+type StatisticsConfig struct {
+	Enabled         bool     `json:"enabled"`
+	Histograms      bool     `json:"histograms"`
+	Correlations    bool     `json:"correlations"`
+	ExactUniqueness bool     `json:"exactUniqueness"`
+	Columns         []string `json:"columns,omitempty"`
+}
+
 type StatisticsResponse struct {
 	Items []Statistics `json:"items"`
 	Count int          `json:"count"`
@@ -46,10 +55,15 @@ type ComputeJobResponse struct {
 	Type string `json:"type,omitempty"`
 }
 
-func (c *Client) GetFeatureGroupStatistics(fgID int, featureNames []string) (*Statistics, error) {
+// GetFeatureGroupStatistics returns the latest statistics, or with asOf
+// (epoch millis, 0 for now) the latest covering commits up to that time.
+func (c *Client) GetFeatureGroupStatistics(fgID int, featureNames []string, asOf int64) (*Statistics, error) {
 	path := fmt.Sprintf("%s/featuregroups/%d/statistics?fields=content&sort_by=computation_time:desc&offset=0&limit=1",
 		c.FSPath(), fgID)
 
+	if asOf > 0 {
+		path += fmt.Sprintf("&filter_by=window_end_commit_time_ltoeq:%d", asOf)
+	}
 	if len(featureNames) > 0 {
 		path += "&feature_names=" + strings.Join(featureNames, ",")
 	}
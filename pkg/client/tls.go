@@ -0,0 +1,113 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/config"
+)
+
+// noClusterCAWarning prints the missing cluster CA warning once per process.
+var noClusterCAWarning sync.Once
+
+// tlsConfig builds the TLS settings for the REST client.
+//
+// Verification is on unless --insecure is given, even inside a pod whose
+// cluster CA can't be found (a warning says so). The system roots are
+// extended with:
+//   - ca_bundle / --ca-cert / HOPS_CA_BUNDLE
+//   - internal mode: the cluster CA from SECRETS_DIR (or DOMAIN_CA_TRUSTSTORE_PEM)
+//   - ca_chain.pem from the hopsfs PEM dir, when present
+//
+// A client certificate for mTLS comes from client_cert/client_key, falling back to
+// client_cert.pem/client_key.pem in the PEM dir.
+func tlsConfig(cfg *config.Config) (*tls.Config, error) {
+	tc := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.Insecure {
+		tc.InsecureSkipVerify = true
+		return tc, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if cfg.CABundle != "" {
+		if err := appendCAFile(pool, cfg.CABundle); err != nil {
+			return nil, fmt.Errorf("ca bundle: %w", err)
+		}
+	}
+
+	clusterCA := false
+	for _, path := range clusterCAPaths(cfg) {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if err := appendCAFile(pool, path); err != nil {
+			return nil, fmt.Errorf("cluster CA: %w", err)
+		}
+		clusterCA = true
+	}
+	tc.RootCAs = pool
+
+	// Verification stays on even without the cluster CA; only --insecure skips it
+	if host := hostURL(cfg.Host); cfg.Internal && !clusterCA && cfg.CABundle == "" && strings.HasPrefix(host, "https://") {
+		noClusterCAWarning.Do(func() {
+			fmt.Fprintf(os.Stderr, "Warning: no cluster CA found in SECRETS_DIR or %s; verifying %s against the system roots (pass --ca-cert, or --insecure to skip verification)\n", config.PemsDir(), host)
+		})
+	}
+
+	certFile, keyFile := cfg.ClientCert, cfg.ClientKey
+	if certFile == "" && keyFile == "" {
+		certFile = filepath.Join(config.PemsDir(), "client_cert.pem")
+		keyFile = filepath.Join(config.PemsDir(), "client_key.pem")
+		if !fileExists(certFile) || !fileExists(keyFile) {
+			return tc, nil
+		}
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("client_cert and client_key must be set together")
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load client certificate: %w", err)
+	}
+	tc.Certificates = []tls.Certificate{cert}
+	return tc, nil
+}
+
+// clusterCAPaths lists candidate cluster CA files, in lookup order.
+func clusterCAPaths(cfg *config.Config) []string {
+	var paths []string
+	if cfg.Internal {
+		if pem := os.Getenv("DOMAIN_CA_TRUSTSTORE_PEM"); pem != "" {
+			paths = append(paths, pem)
+		}
+		if dir := os.Getenv("SECRETS_DIR"); dir != "" {
+			paths = append(paths, filepath.Join(dir, "ca_chain.pem"))
+		}
+	}
+	return append(paths, filepath.Join(config.PemsDir(), "ca_chain.pem"))
+}
+
+func appendCAFile(pool *x509.CertPool, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/MagicLex/hopsworks-cli/pkg/config"
+)
+
+// tokenState tracks the in-pod JWT file so a rotated token is picked up
+// without restarting the CLI. Shared by clients derived via WithContext.
+type tokenState struct {
+	mu  sync.Mutex
+	mod time.Time
+}
+
+func newTokenState(cfg *config.Config) *tokenState {
+	if !cfg.Internal {
+		return nil
+	}
+	return &tokenState{mod: config.TokenModTime()}
+}
+
+// reloadToken re-reads token.jwt when its mtime changed (or always, if force)
+// and reports whether the token in use changed.
+func (c *Client) reloadToken(force bool) bool {
+	ts := c.token
+	if ts == nil {
+		return false
+	}
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if !force && config.TokenModTime().Equal(ts.mod) {
+		return false
+	}
+	token, mod, err := config.ReadToken()
+	if err != nil || token == "" {
+		return false
+	}
+	ts.mod = mod
+	if token == c.Config.JWTToken {
+		return false
+	}
+	c.Config.JWTToken = token
+	return true
+}
+
+// unauthorized builds the error for a 401 that a token reload couldn't fix.
+func (c *Client) unauthorized(status int, data []byte) error {
+	err := apiError(status, data)
+	if c.token != nil && config.TokenFileStale() {
+		return fmt.Errorf("%w (%s): %w", config.ErrTokenExpired, config.TokenPath(), err)
+	}
+	return err
+}
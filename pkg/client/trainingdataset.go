@@ -21,26 +21,25 @@ type TrainingDatasetList struct {
 	Count int               `json:"count"`
 }
 
-func (c *Client) ListTrainingDatasets(fvName string, fvVersion int) ([]TrainingDataset, error) {
+func (c *Client) ListTrainingDatasets(fvName string, fvVersion int, page Page) ([]TrainingDataset, error) {
 	path := fmt.Sprintf("%s/featureview/%s/version/%d/trainingdatasets", c.FSPath(), fvName, fvVersion)
-	data, err := c.Get(path)
-	if err != nil {
-		return nil, err
-	}
+	return listAll(Paginate(c, path, page, parseTrainingDatasets))
+}
 
+func parseTrainingDatasets(data []byte) ([]TrainingDataset, int, error) {
 	var tdList TrainingDatasetList
 	if err := json.Unmarshal(data, &tdList); err == nil {
 		if tdList.Items != nil {
-			return tdList.Items, nil
+			return tdList.Items, tdList.Count, nil
 		}
-		return []TrainingDataset{}, nil
+		return []TrainingDataset{}, 0, nil
 	}
 
 	var tds []TrainingDataset
 	if err := json.Unmarshal(data, &tds); err != nil {
-		return nil, fmt.Errorf("parse training datasets: %w", err)
+		return nil, 0, fmt.Errorf("parse training datasets: %w", err)
 	}
-	return tds, nil
+	return tds, unpaged, nil
 }
 
 func (c *Client) CreateTrainingDataset(fvName string, fvVersion int, description string, dataFormat string) (*TrainingDataset, error) {
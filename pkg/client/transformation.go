@@ -29,25 +29,25 @@ type TransformationFunctionList struct {
 	Count int                      `json:"count"`
 }
 
-func (c *Client) ListTransformationFunctions() ([]TransformationFunction, error) {
-	data, err := c.Get(fmt.Sprintf("%s/transformationfunctions", c.FSPath()))
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListTransformationFunctions(page Page) ([]TransformationFunction, error) {
+	path := fmt.Sprintf("%s/transformationfunctions", c.FSPath())
+	return listAll(Paginate(c, path, page, parseTransformationFunctions))
+}
 
+func parseTransformationFunctions(data []byte) ([]TransformationFunction, int, error) {
 	var tfList TransformationFunctionList
 	if err := json.Unmarshal(data, &tfList); err == nil {
 		if tfList.Items != nil {
-			return tfList.Items, nil
+			return tfList.Items, tfList.Count, nil
 		}
-		return []TransformationFunction{}, nil
+		return []TransformationFunction{}, 0, nil
 	}
 
 	var tfs []TransformationFunction
 	if err := json.Unmarshal(data, &tfs); err != nil {
-		return nil, fmt.Errorf("parse transformation functions: %w", err)
+		return nil, 0, fmt.Errorf("parse transformation functions: %w", err)
 	}
-	return tfs, nil
+	return tfs, unpaged, nil
 }
 
 func (c *Client) GetTransformationFunction(name string, version int) (*TransformationFunction, error) {
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ExpectationSuite is a Great Expectations suite attached to a feature group.
+// Inserts are validated against it; the ingestion policy decides whether
+// data that fails is still written (ALWAYS) or rejected (STRICT).
+type ExpectationSuite struct {
+	ID                        int           `json:"id,omitempty"`
+	FeatureStoreID            int           `json:"featureStoreId,omitempty"`
+	FeatureGroupID            int           `json:"featureGroupId,omitempty"`
+	ExpectationSuiteName      string        `json:"expectationSuiteName"`
+	Expectations              []Expectation `json:"expectations"`
+	Meta                      string        `json:"meta,omitempty"` // JSON object
+	DataAssetType             string        `json:"dataAssetType,omitempty"`
+	RunValidation             bool          `json:"runValidation"`
+	ValidationIngestionPolicy string        `json:"validationIngestionPolicy"`
+}
+
+type Expectation struct {
+	ID              int    `json:"id,omitempty"`
+	ExpectationType string `json:"expectationType"`
+	Kwargs          string `json:"kwargs"`         // JSON object
+	Meta            string `json:"meta,omitempty"` // JSON object
+}
+
+// ValidationReport is the outcome of validating one insert against the suite.
+type ValidationReport struct {
+	ID              int                `json:"id"`
+	Success         bool               `json:"success"`
+	ValidationTime  interface{}        `json:"validationTime,omitempty"`
+	IngestionResult string             `json:"ingestionResult,omitempty"` // INGESTED, REJECTED, EXPERIMENT, ...
+	Statistics      string             `json:"statistics,omitempty"`      // JSON object
+	FullReportPath  string             `json:"fullReportPath,omitempty"`
+	Results         []ValidationResult `json:"results,omitempty"`
+}
+
+type ValidationResult struct {
+	ID                int    `json:"id,omitempty"`
+	Success           bool   `json:"success"`
+	ExpectationConfig string `json:"expectationConfig"` // JSON object
+	Result            string `json:"result,omitempty"`  // JSON object
+	ExceptionInfo     string `json:"exceptionInfo,omitempty"`
+}
+
+type ValidationReportList struct {
+	Items []ValidationReport `json:"items"`
+	Count int                `json:"count"`
+}
+
+// GetExpectationSuite returns the suite attached to a feature group, or nil
+// if it has none.
+func (c *Client) GetExpectationSuite(fgID int) (*ExpectationSuite, error) {
+	data, err := c.Get(fmt.Sprintf("%s/featuregroups/%d/expectationsuite", c.FSPath(), fgID))
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	var suite ExpectationSuite
+	if err := json.Unmarshal(data, &suite); err != nil {
+		return nil, fmt.Errorf("parse expectation suite: %w", err)
+	}
+	if suite.ID == 0 && suite.ExpectationSuiteName == "" {
+		return nil, nil
+	}
+	return &suite, nil
+}
+
+// CreateExpectationSuite attaches a suite to a feature group.
+func (c *Client) CreateExpectationSuite(fgID int, suite *ExpectationSuite) (*ExpectationSuite, error) {
+	body, err := json.Marshal(suite)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.Post(fmt.Sprintf("%s/featuregroups/%d/expectationsuite", c.FSPath(), fgID), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	var created ExpectationSuite
+	if err := json.Unmarshal(data, &created); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	return &created, nil
+}
+
+// UpdateExpectationSuite replaces a feature group's suite in place (suite.ID
+// set), so there is no moment without one.
+func (c *Client) UpdateExpectationSuite(fgID int, suite *ExpectationSuite) (*ExpectationSuite, error) {
+	body, err := json.Marshal(suite)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.Put(fmt.Sprintf("%s/featuregroups/%d/expectationsuite/%d", c.FSPath(), fgID, suite.ID), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	var updated ExpectationSuite
+	if err := json.Unmarshal(data, &updated); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	return &updated, nil
+}
+
+func (c *Client) DeleteExpectationSuite(fgID, suiteID int) error {
+	_, err := c.Delete(fmt.Sprintf("%s/featuregroups/%d/expectationsuite/%d", c.FSPath(), fgID, suiteID))
+	return err
+}
+
+// ListValidationReports lists a feature group's validation reports, newest first.
+func (c *Client) ListValidationReports(fgID int, page Page) ([]ValidationReport, error) {
+	path := fmt.Sprintf("%s/featuregroups/%d/validationreport?sort_by=validation_time:desc&fields=content", c.FSPath(), fgID)
+	return listAll(Paginate(c, path, page, parseValidationReports))
+}
+
+func parseValidationReports(data []byte) ([]ValidationReport, int, error) {
+	var list ValidationReportList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, 0, fmt.Errorf("parse validation reports: %w", err)
+	}
+	return list.Items, list.Count, nil
+}
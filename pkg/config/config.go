@@ -6,18 +6,42 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-
-	"gopkg.in/yaml.v3"
+	"time"
 )
 
 type Config struct {
-	Host           string `yaml:"host"`
-	APIKey         string `yaml:"api_key"`
-	JWTToken       string `yaml:"-"` // Never persisted, loaded from env/file
-	Project        string `yaml:"project"`
-	ProjectID      int    `yaml:"project_id"`
-	FeatureStoreID int    `yaml:"feature_store_id"`
-	Internal       bool   `yaml:"-"` // Auto-detected, never persisted
+	Host   string `yaml:"host"`
+	APIKey string `yaml:"api_key"`
+	// Executable that prints the API key (see ResolveAPIKey); keeps keys out of this file
+	CredentialHelper string `yaml:"credential_helper,omitempty"`
+	JWTToken         string `yaml:"-"` // Never persisted, loaded from env/file
+	Project          string `yaml:"project"`
+	ProjectID        int    `yaml:"project_id"`
+	FeatureStoreID   int    `yaml:"feature_store_id"`
+	FeatureStore     string `yaml:"feature_store,omitempty"` // Name, resolved to FeatureStoreID on use
+	Internal         bool   `yaml:"-"`                       // Auto-detected, never persisted
+	Context          string `yaml:"-"`                       // Name of the context this config was loaded from
+
+	// TLS: extra CA bundle and optional mTLS client material (PEM paths)
+	CABundle   string `yaml:"ca_bundle,omitempty"`
+	ClientCert string `yaml:"client_cert,omitempty"`
+	ClientKey  string `yaml:"client_key,omitempty"`
+	Insecure   bool   `yaml:"-"` // Only via --insecure, never persisted
+
+	// Request tuning, set from global flags and never persisted
+	Timeout time.Duration `yaml:"-"`
+	Retries int           `yaml:"-"`
+
+	// HTTP tracing (--debug / HOPS_DEBUG, --debug-dir / HOPS_DEBUG_DIR)
+	Debug    bool   `yaml:"-"`
+	DebugDir string `yaml:"-"`
+
+	// Pinned is the .hops.yaml found above the working directory, if any
+	Pinned *ProjectFile `yaml:"-"`
+	// Origins maps a config key (host, project, ...) to where its value came from
+	Origins map[string]string `yaml:"-"`
+
+	contextHost string // host of the loaded context, checked against a pinned one
 }
 
 func ConfigDir() string {
@@ -29,80 +53,182 @@ func ConfigPath() string {
 	return filepath.Join(ConfigDir(), "config")
 }
 
-func Load() (*Config, error) {
-	cfg := &Config{}
+// PemsDir is where the PEM material extracted from the cluster JKS lives
+// (client_cert.pem, client_key.pem, ca_chain.pem). Shared with the Python path.
+func PemsDir() string {
+	if dir := os.Getenv("PEMS_DIR"); dir != "" {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".hopsfs_pems")
+}
+
+// Load builds the effective config for the named context ("" picks
+// HOPS_CONTEXT or the file's current context). Precedence, highest first:
+// flags (applied by the caller), env vars, .hops.yaml, ~/.hops/config.
+// A host pinned by .hops.yaml is only used after CheckPinnedHost. An
+// explicit context that doesn't exist yields an *UnknownContextError.
+func Load(context string) (*Config, error) {
+	cfg := &Config{Origins: map[string]string{}}
 
 	// Detect internal mode: inside a Hopsworks terminal pod
 	cfg.Internal = os.Getenv("REST_ENDPOINT") != "" && os.Getenv("SECRETS_DIR") != ""
 
-	if cfg.Internal {
-		cfg.Host = os.Getenv("REST_ENDPOINT")
-		if project := os.Getenv("PROJECT_NAME"); project != "" {
-			cfg.Project = project
-		}
-		if projectID := os.Getenv("HOPSWORKS_PROJECT_ID"); projectID != "" {
-			if id, err := strconv.Atoi(projectID); err == nil {
-				cfg.ProjectID = id
-			}
+	env := &Config{
+		Host:     os.Getenv("REST_ENDPOINT"),
+		Project:  os.Getenv("PROJECT_NAME"),
+		CABundle: os.Getenv("HOPS_CA_BUNDLE"),
+	}
+	if projectID := os.Getenv("HOPSWORKS_PROJECT_ID"); projectID != "" {
+		if id, err := strconv.Atoi(projectID); err == nil {
+			env.ProjectID = id
 		}
-		// JWT from secrets mount
-		tokenPath := filepath.Join(os.Getenv("SECRETS_DIR"), "token.jwt")
-		if tokenData, err := os.ReadFile(tokenPath); err == nil {
-			cfg.JWTToken = strings.TrimSpace(string(tokenData))
+	}
+	if cfg.Internal {
+		// JWT from secrets mount (the client re-reads it when it rotates)
+		if token, _, err := ReadToken(); err == nil {
+			cfg.JWTToken = token
 		}
 	} else {
-		// External mode: env vars can still override config file
-		if endpoint := os.Getenv("REST_ENDPOINT"); endpoint != "" {
-			cfg.Host = endpoint
+		env.APIKey = os.Getenv("HOPSWORKS_API_KEY")
+	}
+	cfg.merge(env, "env")
+	for key, name := range map[string]string{
+		"host": "REST_ENDPOINT", "api_key": "HOPSWORKS_API_KEY", "project": "PROJECT_NAME",
+		"project_id": "HOPSWORKS_PROJECT_ID", "ca_bundle": "HOPS_CA_BUNDLE",
+	} {
+		if cfg.Origins[key] == "env" {
+			cfg.Origins[key] = "env " + name
 		}
-		if apiKey := os.Getenv("HOPSWORKS_API_KEY"); apiKey != "" {
-			cfg.APIKey = apiKey
+	}
+
+	cfg.Debug = os.Getenv("HOPS_DEBUG") == "1" || strings.EqualFold(os.Getenv("HOPS_DEBUG"), "true")
+	cfg.DebugDir = os.Getenv("HOPS_DEBUG_DIR")
+
+	// Per-directory pins
+	pinned, err := FindProjectFile()
+	if err != nil {
+		return cfg, err
+	}
+	if pinned != nil {
+		cfg.Pinned = pinned
+		cfg.merge(&pinned.Config, pinned.Path)
+	}
+
+	// User config file (values don't override the above)
+	file, err := LoadFile()
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Context, cfg.Origins["context"] = file.resolveContext(context)
+	fileCfg, ok := file.Contexts[cfg.Context]
+	if !ok {
+		// Falling back to another context would target the wrong cluster
+		if context != "" || os.Getenv("HOPS_CONTEXT") != "" {
+			return cfg, &UnknownContextError{Name: cfg.Context, Origin: cfg.Origins["context"], Available: file.Names()}
 		}
-		if project := os.Getenv("PROJECT_NAME"); project != "" {
-			cfg.Project = project
+		return cfg, nil
+	}
+	cfg.contextHost = fileCfg.Host
+	cfg.merge(fileCfg, fmt.Sprintf("%s (context %s)", ConfigPath(), cfg.Context))
+
+	return cfg, nil
+}
+
+// merge fills unset fields from a lower-precedence source and records origins.
+// Project and feature store IDs are only taken when they belong to the
+// project already chosen, so pinning a project by name never mixes in the
+// IDs of another one.
+func (c *Config) merge(src *Config, origin string) {
+	setStr := func(key string, dst *string, v string) {
+		if *dst == "" && v != "" {
+			*dst = v
+			c.Origins[key] = origin
 		}
-		if projectID := os.Getenv("HOPSWORKS_PROJECT_ID"); projectID != "" {
-			if id, err := strconv.Atoi(projectID); err == nil {
-				cfg.ProjectID = id
-			}
+	}
+	setInt := func(key string, dst *int, v int) {
+		if *dst == 0 && v != 0 {
+			*dst = v
+			c.Origins[key] = origin
 		}
 	}
 
-	// Load config file (values don't override env vars)
-	data, err := os.ReadFile(ConfigPath())
-	if err == nil {
-		var fileCfg Config
-		if err := yaml.Unmarshal(data, &fileCfg); err == nil {
-			if cfg.Host == "" {
-				cfg.Host = fileCfg.Host
-			}
-			if cfg.APIKey == "" {
-				cfg.APIKey = fileCfg.APIKey
-			}
-			if cfg.Project == "" {
-				cfg.Project = fileCfg.Project
-			}
-			if cfg.ProjectID == 0 {
-				cfg.ProjectID = fileCfg.ProjectID
-			}
-			if cfg.FeatureStoreID == 0 {
-				cfg.FeatureStoreID = fileCfg.FeatureStoreID
-			}
+	sameProject := c.Project == "" || src.Project == "" || c.Project == src.Project
+	setStr("host", &c.Host, src.Host)
+	setStr("api_key", &c.APIKey, src.APIKey)
+	setStr("credential_helper", &c.CredentialHelper, src.CredentialHelper)
+	setStr("project", &c.Project, src.Project)
+	if sameProject {
+		setInt("project_id", &c.ProjectID, src.ProjectID)
+		// An ID without a name may be another feature store than the one chosen
+		if c.FeatureStore == "" || c.FeatureStore == src.FeatureStore {
+			setInt("feature_store_id", &c.FeatureStoreID, src.FeatureStoreID)
 		}
+		setStr("feature_store", &c.FeatureStore, src.FeatureStore)
 	}
-
-	return cfg, nil
+	setStr("ca_bundle", &c.CABundle, src.CABundle)
+	setStr("client_cert", &c.ClientCert, src.ClientCert)
+	setStr("client_key", &c.ClientKey, src.ClientKey)
 }
 
+// Save stores the config as its context in ~/.hops/config, creating the
+// context if needed. Other contexts are left untouched. Only values read from
+// the config file or set by a command on purpose ('hops login', 'hops project
+// use') are written; those from flags, env vars or .hops.yaml keep the
+// context's previous value, and so do API keys from anywhere but the config
+// file or 'hops login'.
 func (c *Config) Save() error {
-	if err := os.MkdirAll(ConfigDir(), 0700); err != nil {
-		return fmt.Errorf("create config dir: %w", err)
-	}
-	data, err := yaml.Marshal(c)
+	file, err := LoadFile()
 	if err != nil {
-		return fmt.Errorf("marshal config: %w", err)
+		return err
 	}
-	return os.WriteFile(ConfigPath(), data, 0600)
+	name := c.Context
+	if name == "" {
+		name = DefaultContext
+	}
+	prev := file.Contexts[name]
+	if prev == nil {
+		prev = &Config{}
+	}
+
+	saved := *c
+	if !c.apiKeyStorable() {
+		saved.APIKey = prev.APIKey
+	}
+	keep := func(key string, dst *string, v string) {
+		if !c.storable(key) {
+			*dst = v
+		}
+	}
+	keep("host", &saved.Host, prev.Host)
+	keep("credential_helper", &saved.CredentialHelper, prev.CredentialHelper)
+	keep("ca_bundle", &saved.CABundle, prev.CABundle)
+	keep("client_cert", &saved.ClientCert, prev.ClientCert)
+	keep("client_key", &saved.ClientKey, prev.ClientKey)
+	// IDs resolved for a project from elsewhere belong to that project, not the context's
+	switch {
+	case !c.storable("project") || !c.storable("project_id"):
+		saved.Project, saved.ProjectID = prev.Project, prev.ProjectID
+		saved.FeatureStore, saved.FeatureStoreID = prev.FeatureStore, prev.FeatureStoreID
+	case !c.storable("feature_store") || !c.storable("feature_store_id"):
+		saved.FeatureStore, saved.FeatureStoreID = prev.FeatureStore, prev.FeatureStoreID
+		if saved.Project != prev.Project {
+			saved.FeatureStore, saved.FeatureStoreID = "", 0 // resolved again on use
+		}
+	}
+	file.Contexts[name] = &saved
+	if file.CurrentContext == "" {
+		file.CurrentContext = name
+	}
+	return file.Save()
+}
+
+// storable reports whether Save may write the value of key: one read from
+// the config file, set by a command (origin "hops ...") or by code (no
+// origin), not one from a flag, env var, .hops.yaml or credential helper.
+func (c *Config) storable(key string) bool {
+	origin := c.Origins[key]
+	return origin == "" || strings.HasPrefix(origin, "hops ") || strings.HasPrefix(origin, ConfigPath()+" ")
 }
 
 func (c *Config) Validate() error {
@@ -110,7 +236,7 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("no host configured. Run 'hops login' or set REST_ENDPOINT")
 	}
 	if c.APIKey == "" && c.JWTToken == "" {
-		return fmt.Errorf("no API key or JWT token. Run 'hops login' or set HOPSWORKS_API_KEY")
+		return fmt.Errorf("no API key or JWT token. Run 'hops login', set HOPSWORKS_API_KEY or configure credential_helper")
 	}
 	return nil
 }
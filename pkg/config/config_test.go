@@ -0,0 +1,316 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testHome points HOME and the working directory at empty temp dirs and
+// clears the env vars Load reads. It returns the working directory.
+func testHome(t *testing.T) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	for _, name := range []string{
+		"REST_ENDPOINT", "SECRETS_DIR", "PROJECT_NAME", "HOPSWORKS_PROJECT_ID", "HOPSWORKS_API_KEY",
+		"HOPS_CA_BUNDLE", "HOPS_CONTEXT", "HOPS_DEBUG", "HOPS_DEBUG_DIR",
+	} {
+		t.Setenv(name, "")
+	}
+	dir := t.TempDir()
+	t.Chdir(dir)
+	return dir
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+const testConfig = `current_context: prod
+contexts:
+  prod:
+    host: https://prod.example.com
+    api_key: filekey
+    project: fraud
+    project_id: 119
+    feature_store_id: 67
+    ca_bundle: /etc/prod-ca.pem
+  staging:
+    host: https://staging.example.com
+    api_key: stagingkey
+`
+
+func TestLoadFileMigratesLegacy(t *testing.T) {
+	testHome(t)
+	writeFile(t, ConfigPath(), "host: https://old.example.com\napi_key: oldkey\nproject: fraud\nproject_id: 119\n")
+
+	f, err := LoadFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Config{Host: "https://old.example.com", APIKey: "oldkey", Project: "fraud", ProjectID: 119}
+	if f.CurrentContext != DefaultContext || !reflect.DeepEqual(f.Contexts[DefaultContext], want) {
+		t.Errorf("migrated to %q: %+v, want %q: %+v", f.CurrentContext, f.Contexts[DefaultContext], DefaultContext, want)
+	}
+
+	// The file is rewritten with contexts, so a second load reads it as is
+	data, err := os.ReadFile(ConfigPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "current_context: default") || !strings.Contains(string(data), "contexts:") {
+		t.Errorf("config not rewritten:\n%s", data)
+	}
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Host != want.Host || cfg.Origins["host"] != ConfigPath()+" (context default)" {
+		t.Errorf("host %q from %q after migration", cfg.Host, cfg.Origins["host"])
+	}
+
+	// An empty file is not a legacy profile
+	writeFile(t, ConfigPath(), "")
+	if f, err = LoadFile(); err != nil || len(f.Contexts) != 0 {
+		t.Errorf("empty file: %+v, %v", f, err)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	fileOrigin := "(context prod)"
+	tests := []struct {
+		name    string
+		env     map[string]string
+		pin     string // .hops.yaml
+		context string
+		want    Config
+		origins map[string]string // key -> a substring of its origin
+	}{
+		{
+			name:    "file",
+			want:    Config{Host: "https://prod.example.com", APIKey: "filekey", Project: "fraud", ProjectID: 119, FeatureStoreID: 67, CABundle: "/etc/prod-ca.pem"},
+			origins: map[string]string{"host": fileOrigin, "project_id": fileOrigin},
+		},
+		{
+			name:    "context flag",
+			context: "staging",
+			want:    Config{Host: "https://staging.example.com", APIKey: "stagingkey"},
+			origins: map[string]string{"host": "(context staging)", "context": "flag --context"},
+		},
+		{
+			name:    "env context",
+			env:     map[string]string{"HOPS_CONTEXT": "staging"},
+			want:    Config{Host: "https://staging.example.com", APIKey: "stagingkey"},
+			origins: map[string]string{"context": "env HOPS_CONTEXT"},
+		},
+		{
+			name: "project file over config file",
+			pin:  "project: fraud\nfeature_store: shared_featurestore\n",
+			// The file's feature store ID is for another feature store
+			want:    Config{Host: "https://prod.example.com", APIKey: "filekey", Project: "fraud", ProjectID: 119, FeatureStore: "shared_featurestore", CABundle: "/etc/prod-ca.pem"},
+			origins: map[string]string{"project": ProjectFileName, "feature_store": ProjectFileName, "project_id": fileOrigin},
+		},
+		{
+			name: "other project pinned",
+			pin:  "project: churn\n",
+			// IDs of the file's project don't carry over
+			want:    Config{Host: "https://prod.example.com", APIKey: "filekey", Project: "churn", CABundle: "/etc/prod-ca.pem"},
+			origins: map[string]string{"project": ProjectFileName},
+		},
+		{
+			name: "env over project file",
+			env: map[string]string{
+				"REST_ENDPOINT": "https://env.example.com", "HOPSWORKS_API_KEY": "envkey",
+				"PROJECT_NAME": "churn", "HOPSWORKS_PROJECT_ID": "5", "HOPS_CA_BUNDLE": "/env-ca.pem",
+			},
+			pin:  "project: fraud\n",
+			want: Config{Host: "https://env.example.com", APIKey: "envkey", Project: "churn", ProjectID: 5, CABundle: "/env-ca.pem"},
+			origins: map[string]string{
+				"host": "env REST_ENDPOINT", "api_key": "env HOPSWORKS_API_KEY", "project": "env PROJECT_NAME",
+				"project_id": "env HOPSWORKS_PROJECT_ID", "ca_bundle": "env HOPS_CA_BUNDLE",
+			},
+		},
+		{
+			name:    "project file secrets ignored",
+			pin:     "api_key: repokey\ncredential_helper: ./steal\nca_bundle: /repo-ca.pem\n",
+			want:    Config{Host: "https://prod.example.com", APIKey: "filekey", Project: "fraud", ProjectID: 119, FeatureStoreID: 67, CABundle: "/etc/prod-ca.pem"},
+			origins: map[string]string{"api_key": fileOrigin, "ca_bundle": fileOrigin},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testHome(t)
+			writeFile(t, ConfigPath(), testConfig)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if tt.pin != "" {
+				writeFile(t, filepath.Join(dir, ProjectFileName), tt.pin)
+			}
+
+			cfg, err := Load(tt.context)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := Config{
+				Host: cfg.Host, APIKey: cfg.APIKey, Project: cfg.Project, ProjectID: cfg.ProjectID,
+				FeatureStoreID: cfg.FeatureStoreID, FeatureStore: cfg.FeatureStore, CABundle: cfg.CABundle,
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+			for key, want := range tt.origins {
+				if !strings.Contains(cfg.Origins[key], want) {
+					t.Errorf("origin of %s = %q, want it to contain %q", key, cfg.Origins[key], want)
+				}
+			}
+		})
+	}
+
+	t.Run("unknown context", func(t *testing.T) {
+		testHome(t)
+		writeFile(t, ConfigPath(), testConfig)
+		_, err := Load("dev")
+		if _, ok := err.(*UnknownContextError); !ok {
+			t.Errorf("err = %v, want an UnknownContextError", err)
+		}
+	})
+}
+
+func TestSaveRoundTrip(t *testing.T) {
+	prod := Config{Host: "https://prod.example.com", APIKey: "filekey", Project: "fraud", ProjectID: 119, FeatureStoreID: 67, CABundle: "/etc/prod-ca.pem"}
+	tests := []struct {
+		name  string
+		env   map[string]string
+		pin   string
+		apply func(c *Config) // what flags or a command do after Load
+		want  Config          // the prod context after Save
+	}{
+		{
+			name:  "unchanged",
+			apply: func(c *Config) {},
+			want:  prod,
+		},
+		{
+			name: "flags",
+			apply: func(c *Config) {
+				// As the root command applies --host, --api-key, --project and --ca-cert
+				c.Host, c.Origins["host"] = "https://other.example.com", "flag --host"
+				c.APIKey, c.Origins["api_key"] = "flagkey", "flag --api-key"
+				c.Project, c.ProjectID, c.FeatureStoreID, c.Origins["project"] = "churn", 0, 0, "flag --project"
+				c.CABundle, c.Origins["ca_bundle"] = "/flag-ca.pem", "flag --ca-cert"
+				// then mustClient resolves the IDs of --project
+				c.ProjectID, c.FeatureStoreID = 5, 8
+			},
+			want: prod,
+		},
+		{
+			name: "env",
+			env: map[string]string{
+				"REST_ENDPOINT": "https://env.example.com", "HOPSWORKS_API_KEY": "envkey",
+				"PROJECT_NAME": "churn", "HOPSWORKS_PROJECT_ID": "5", "HOPS_CA_BUNDLE": "/env-ca.pem",
+			},
+			apply: func(c *Config) {},
+			want:  prod,
+		},
+		{
+			name:  "project file",
+			pin:   "project: churn\nfeature_store: shared_featurestore\n",
+			apply: func(c *Config) { c.ProjectID, c.FeatureStoreID = 5, 8 },
+			want:  prod,
+		},
+		{
+			name: "project use",
+			apply: func(c *Config) {
+				c.Project, c.ProjectID, c.FeatureStoreID = "churn", 5, 8
+				c.Origins["project"], c.Origins["project_id"] = OriginProjectUse, OriginProjectUse
+			},
+			want: Config{Host: "https://prod.example.com", APIKey: "filekey", Project: "churn", ProjectID: 5, FeatureStoreID: 8, CABundle: "/etc/prod-ca.pem"},
+		},
+		{
+			name: "project use with a pinned feature store",
+			pin:  "feature_store: shared_featurestore\n",
+			apply: func(c *Config) {
+				c.Project, c.ProjectID, c.FeatureStoreID = "churn", 5, 8
+				c.Origins["project"], c.Origins["project_id"] = OriginProjectUse, OriginProjectUse
+			},
+			// The pinned feature store isn't saved, and the old ID is another project's
+			want: Config{Host: "https://prod.example.com", APIKey: "filekey", Project: "churn", ProjectID: 5, CABundle: "/etc/prod-ca.pem"},
+		},
+		{
+			name: "login",
+			apply: func(c *Config) {
+				c.Host, c.Origins["host"] = "https://new.example.com", OriginLogin
+				c.APIKey, c.Origins["api_key"] = "newkey", OriginLogin
+				c.Project, c.ProjectID, c.FeatureStoreID = "churn", 5, 8
+				c.Origins["project"], c.Origins["project_id"] = OriginLogin, OriginLogin
+			},
+			want: Config{Host: "https://new.example.com", APIKey: "newkey", Project: "churn", ProjectID: 5, FeatureStoreID: 8, CABundle: "/etc/prod-ca.pem"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testHome(t)
+			writeFile(t, ConfigPath(), testConfig)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if tt.pin != "" {
+				writeFile(t, filepath.Join(dir, ProjectFileName), tt.pin)
+			}
+
+			cfg, err := Load("")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.apply(cfg)
+			if err := cfg.Save(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := LoadFile()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := f.Contexts["prod"]; !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("saved\n got %+v\nwant %+v", *got, tt.want)
+			}
+			if got := f.Contexts["staging"]; got == nil || got.APIKey != "stagingkey" {
+				t.Errorf("other context changed: %+v", got)
+			}
+			if f.CurrentContext != "prod" {
+				t.Errorf("current_context = %q, want prod", f.CurrentContext)
+			}
+		})
+	}
+
+	t.Run("new context", func(t *testing.T) {
+		testHome(t)
+		cfg := &Config{Host: "https://new.example.com", APIKey: "newkey", Origins: map[string]string{"api_key": OriginLogin}}
+		if err := cfg.Save(); err != nil {
+			t.Fatal(err)
+		}
+		info, err := os.Stat(ConfigPath())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("config mode %v, want 0600", info.Mode().Perm())
+		}
+		f, err := LoadFile()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := f.Contexts[DefaultContext]; f.CurrentContext != DefaultContext || got == nil || got.APIKey != "newkey" {
+			t.Errorf("saved %q: %+v", f.CurrentContext, got)
+		}
+	})
+}
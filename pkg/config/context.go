@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultContext is the context name used for fresh and migrated config files.
+const DefaultContext = "default"
+
+// File is the on-disk layout of ~/.hops/config: named contexts (host, key,
+// project, feature store) plus the one currently in use.
+type File struct {
+	CurrentContext string             `yaml:"current_context"`
+	Contexts       map[string]*Config `yaml:"contexts"`
+}
+
+// LoadFile reads ~/.hops/config. A missing file yields an empty File.
+// Pre-context files (a single top-level profile) are migrated to a
+// "default" context and rewritten in place.
+func LoadFile() (*File, error) {
+	f := &File{Contexts: map[string]*Config{}}
+
+	data, err := os.ReadFile(ConfigPath())
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	if f.Contexts == nil {
+		f.Contexts = map[string]*Config{}
+	}
+
+	if len(f.Contexts) == 0 {
+		var legacy Config
+		if err := yaml.Unmarshal(data, &legacy); err == nil && (legacy.Host != "" || legacy.APIKey != "") {
+			f.Contexts[DefaultContext] = &legacy
+			f.CurrentContext = DefaultContext
+			if err := f.Save(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not migrate config to contexts: %v\n", err)
+			}
+		}
+	}
+	return f, nil
+}
+
+// Save writes the file with owner-only permissions.
+func (f *File) Save() error {
+	if err := os.MkdirAll(ConfigDir(), 0700); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+	return os.WriteFile(ConfigPath(), data, 0600)
+}
+
+// Names returns the context names, sorted.
+func (f *File) Names() []string {
+	names := make([]string, 0, len(f.Contexts))
+	for name := range f.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UnknownContextError is returned by Load when --context or HOPS_CONTEXT
+// names a context that isn't in the config file.
+type UnknownContextError struct {
+	Name      string
+	Origin    string
+	Available []string
+}
+
+func (e *UnknownContextError) Error() string {
+	if len(e.Available) == 0 {
+		return fmt.Sprintf("context '%s' (%s) not found: no contexts configured. Run 'hops login --context %s' to create it", e.Name, e.Origin, e.Name)
+	}
+	return fmt.Sprintf("context '%s' (%s) not found. Available: %s", e.Name, e.Origin, strings.Join(e.Available, ", "))
+}
+
+// resolveContext picks the context to use: explicit name (--context), then
+// HOPS_CONTEXT, then current_context, then "default". It also reports why.
+func (f *File) resolveContext(name string) (string, string) {
+	if name != "" {
+		return name, "flag --context"
+	}
+	if env := os.Getenv("HOPS_CONTEXT"); env != "" {
+		return env, "env HOPS_CONTEXT"
+	}
+	if f.CurrentContext != "" {
+		return f.CurrentContext, ConfigPath() + " (current_context)"
+	}
+	return DefaultContext, "default"
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// credentialHelperTimeout bounds a helper run (it may prompt, e.g. for a vault unlock).
+const credentialHelperTimeout = 2 * time.Minute
+
+// CredentialRequest is written as JSON to the helper's stdin. The helper is run
+// as `<credential_helper> get` and answers on stdout with either the bare key
+// or {"api_key": "..."}.
+type CredentialRequest struct {
+	Operation string `json:"operation"`
+	Host      string `json:"host"`
+	Project   string `json:"project,omitempty"`
+	Context   string `json:"context,omitempty"`
+}
+
+type credentialResponse struct {
+	APIKey string `json:"api_key"`
+}
+
+// Keys fetched from a helper, cached for the life of the process
+var (
+	credentialMu    sync.Mutex
+	credentialCache = map[string]string{}
+)
+
+// ResolveAPIKey fills APIKey from the credential helper when no key or JWT is
+// set. It's a no-op without a helper.
+func (c *Config) ResolveAPIKey() error {
+	if c.APIKey != "" || c.JWTToken != "" || c.CredentialHelper == "" || c.Host == "" {
+		return nil
+	}
+
+	req := CredentialRequest{Operation: "get", Host: c.Host, Project: c.Project, Context: c.Context}
+	cacheKey := req.Host + "\x00" + req.Project
+
+	credentialMu.Lock()
+	defer credentialMu.Unlock()
+	if key, ok := credentialCache[cacheKey]; ok {
+		c.setHelperKey(key)
+		return nil
+	}
+
+	key, err := runCredentialHelper(c.CredentialHelper, req)
+	if err != nil {
+		return err
+	}
+	credentialCache[cacheKey] = key
+	c.setHelperKey(key)
+	return nil
+}
+
+func (c *Config) setHelperKey(key string) {
+	c.APIKey = key
+	if c.Origins != nil {
+		c.Origins["api_key"] = "credential_helper " + c.CredentialHelper
+	}
+}
+
+// Origins of values a command sets on purpose, which Save writes to the
+// config file (an API key only from 'hops login').
+const (
+	OriginLogin      = "hops login"
+	OriginProjectUse = "hops project use"
+)
+
+// apiKeyStorable reports whether Save may write the current APIKey: only a
+// key read from the config file or given to 'hops login'. Keys from env vars,
+// --api-key or the credential helper stay off disk.
+func (c *Config) apiKeyStorable() bool {
+	origin := c.Origins["api_key"]
+	return origin == OriginLogin || strings.HasPrefix(origin, ConfigPath()+" ")
+}
+
+func runCredentialHelper(helper string, req CredentialRequest) (string, error) {
+	args := strings.Fields(helper)
+	if len(args) == 0 {
+		return "", fmt.Errorf("credential_helper is empty")
+	}
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), credentialHelperTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, args[0], append(args[1:], req.Operation)...)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stderr = os.Stderr // Helpers may prompt or explain failures
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("credential helper %s: %w", args[0], err)
+	}
+
+	text := strings.TrimSpace(string(out))
+	if strings.HasPrefix(text, "{") {
+		var resp credentialResponse
+		if err := json.Unmarshal([]byte(text), &resp); err != nil {
+			return "", fmt.Errorf("credential helper %s: parse response: %w", args[0], err)
+		}
+		text = strings.TrimSpace(resp.APIKey)
+	}
+	if text == "" {
+		return "", fmt.Errorf("credential helper %s returned no API key for %s", args[0], req.Host)
+	}
+	return text, nil
+}
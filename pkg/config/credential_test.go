@@ -0,0 +1,173 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeHelper writes a shell script that logs its arguments and stdin to
+// dir/calls, then runs body. It returns the script's path.
+func fakeHelper(t *testing.T, dir, body string) string {
+	t.Helper()
+	path := filepath.Join(dir, "helper.sh")
+	script := "#!/bin/sh\necho \"$@\" >> " + filepath.Join(dir, "calls") + "\ncat >> " + filepath.Join(dir, "calls") + "\necho >> " + filepath.Join(dir, "calls") + "\n" + body + "\n"
+	if err := os.WriteFile(path, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// helperCalls returns the logged runs of a fake helper: arguments, then the
+// request it read.
+func helperCalls(t *testing.T, dir string) [][2]string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, "calls"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	var calls [][2]string
+	for i := 0; i+1 < len(lines); i += 2 {
+		calls = append(calls, [2]string{lines[i], lines[i+1]})
+	}
+	return calls
+}
+
+func TestResolveAPIKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		args    string
+		want    string
+		wantErr string
+	}{
+		{name: "bare key", body: "echo '  helperkey  '", want: "helperkey"},
+		{name: "json", body: `echo '{"api_key": "jsonkey"}'`, want: "jsonkey"},
+		{name: "arguments", body: "echo argkey", args: " --vault prod", want: "argkey"},
+		{name: "failure", body: "echo locked >&2; exit 3", wantErr: "exit status 3"},
+		{name: "no key", body: "echo", wantErr: "returned no API key"},
+		{name: "empty json", body: `echo '{"api_key": ""}'`, wantErr: "returned no API key"},
+		{name: "bad json", body: `echo '{"api_key":'`, wantErr: "parse response"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			// Keys are cached per host and project, so each case has its own host
+			host := "https://" + strings.ReplaceAll(tt.name, " ", "-") + ".example.com"
+			cfg := &Config{
+				Host: host, Project: "fraud", Context: "prod",
+				CredentialHelper: fakeHelper(t, dir, tt.body) + tt.args,
+				Origins:          map[string]string{},
+			}
+
+			err := cfg.ResolveAPIKey()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("err = %v, want it to contain %q", err, tt.wantErr)
+				}
+				if cfg.APIKey != "" {
+					t.Errorf("APIKey = %q after a failed helper", cfg.APIKey)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.APIKey != tt.want || !strings.HasPrefix(cfg.Origins["api_key"], "credential_helper ") {
+				t.Errorf("APIKey %q from %q, want %q from the helper", cfg.APIKey, cfg.Origins["api_key"], tt.want)
+			}
+
+			calls := helperCalls(t, dir)
+			if len(calls) != 1 {
+				t.Fatalf("helper ran %d times, want 1", len(calls))
+			}
+			if want := strings.TrimSpace(tt.args + " get"); calls[0][0] != want {
+				t.Errorf("helper args %q, want %q", calls[0][0], want)
+			}
+			var req CredentialRequest
+			if err := json.Unmarshal([]byte(calls[0][1]), &req); err != nil {
+				t.Fatalf("request %q: %v", calls[0][1], err)
+			}
+			if want := (CredentialRequest{Operation: "get", Host: host, Project: "fraud", Context: "prod"}); req != want {
+				t.Errorf("request %+v, want %+v", req, want)
+			}
+
+			// The key is cached for the process
+			again := &Config{Host: host, Project: "fraud", CredentialHelper: cfg.CredentialHelper}
+			if err := again.ResolveAPIKey(); err != nil || again.APIKey != tt.want {
+				t.Errorf("cached key %q, %v", again.APIKey, err)
+			}
+			if n := len(helperCalls(t, dir)); n != 1 {
+				t.Errorf("helper ran %d times with a cached key, want 1", n)
+			}
+		})
+	}
+
+	t.Run("not needed", func(t *testing.T) {
+		dir := t.TempDir()
+		helper := fakeHelper(t, dir, "echo helperkey")
+		for _, cfg := range []*Config{
+			{Host: "https://set.example.com", APIKey: "filekey", CredentialHelper: helper},
+			{Host: "https://set.example.com", JWTToken: "jwt", CredentialHelper: helper},
+			{CredentialHelper: helper},
+		} {
+			if err := cfg.ResolveAPIKey(); err != nil {
+				t.Error(err)
+			}
+		}
+		if n := len(helperCalls(t, dir)); n != 0 {
+			t.Errorf("helper ran %d times, want 0", n)
+		}
+	})
+
+	t.Run("blank helper", func(t *testing.T) {
+		cfg := &Config{Host: "https://blank.example.com", CredentialHelper: "  "}
+		if err := cfg.ResolveAPIKey(); err == nil || !strings.Contains(err.Error(), "credential_helper is empty") {
+			t.Errorf("err = %v", err)
+		}
+	})
+}
+
+func TestSaveSkipsHelperKey(t *testing.T) {
+	testHome(t)
+	helper := fakeHelper(t, t.TempDir(), "echo helperkey")
+	writeFile(t, ConfigPath(), "current_context: prod\ncontexts:\n  prod:\n    host: https://save.example.com\n    credential_helper: "+helper+"\n    project: fraud\n")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.ResolveAPIKey(); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.APIKey != "helperkey" {
+		t.Fatalf("APIKey = %q, want the helper's", cfg.APIKey)
+	}
+	// As mustClient does after resolving the project
+	cfg.ProjectID, cfg.FeatureStoreID = 119, 67
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(ConfigPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "helperkey") {
+		t.Errorf("helper key written to the config:\n%s", data)
+	}
+	f, err := LoadFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := f.Contexts["prod"]
+	if got.APIKey != "" || got.CredentialHelper != helper || got.ProjectID != 119 {
+		t.Errorf("saved %+v", got)
+	}
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName is the per-directory settings file, found by walking up
+// from the working directory.
+const ProjectFileName = ".hops.yaml"
+
+// ProjectFile pins connection settings and default versions for a repo:
+//
+//	host: https://staging.hops.example.com
+//	project: fraud
+//	feature_store: fraud_featurestore
+//	versions:
+//	  fg: {transactions: 2}
+//	  fv: {fraud_view: 1}
+//	  model: {fraud_model: 3}
+type ProjectFile struct {
+	Config   `yaml:",inline"`
+	Versions map[string]map[string]int `yaml:"versions,omitempty"` // fg|fv|model -> name -> version
+	Path     string                    `yaml:"-"`
+}
+
+// FindProjectFile walks up from the working directory and loads the first
+// .hops.yaml found. It returns nil when there is none.
+func FindProjectFile() (*ProjectFile, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, nil
+	}
+	for {
+		path := filepath.Join(dir, ProjectFileName)
+		if data, err := os.ReadFile(path); err == nil {
+			pf := &ProjectFile{Path: path}
+			if err := yaml.Unmarshal(data, pf); err != nil {
+				return nil, fmt.Errorf("parse %s: %w", path, err)
+			}
+			// Credentials belong in the user config or a credential store, not in a repo
+			if pf.APIKey != "" {
+				fmt.Fprintf(os.Stderr, "Warning: ignoring api_key in %s\n", path)
+				pf.APIKey = ""
+			}
+			// A repo must not choose what executable runs with our credentials
+			if pf.CredentialHelper != "" {
+				fmt.Fprintf(os.Stderr, "Warning: ignoring credential_helper in %s\n", path)
+				pf.CredentialHelper = ""
+			}
+			// Nor which certificates we trust (insecure is never read from files)
+			for _, tls := range []struct {
+				key string
+				v   *string
+			}{{"ca_bundle", &pf.CABundle}, {"client_cert", &pf.ClientCert}, {"client_key", &pf.ClientKey}} {
+				if *tls.v != "" {
+					fmt.Fprintf(os.Stderr, "Warning: ignoring %s in %s\n", tls.key, path)
+					*tls.v = ""
+				}
+			}
+			return pf, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// CheckPinnedHost refuses a host pinned by .hops.yaml that differs from the
+// context's: the API key comes from the user's config, so a cloned repo must
+// not pick where it is sent. trust (--trust-project-host) accepts the pin.
+func (c *Config) CheckPinnedHost(trust bool) error {
+	p := c.Pinned
+	if p == nil || p.Host == "" || c.Origins["host"] != p.Path || trust {
+		return nil
+	}
+	if sameHost(p.Host, c.contextHost) {
+		return nil
+	}
+	current := fmt.Sprintf("context '%s' uses %s", c.Context, c.contextHost)
+	if c.contextHost == "" {
+		current = fmt.Sprintf("context '%s' has no host", c.Context)
+	}
+	return fmt.Errorf("%s pins host %s but %s; pass --trust-project-host to send your credentials there, or --host to pick one", p.Path, p.Host, current)
+}
+
+func sameHost(a, b string) bool {
+	return strings.EqualFold(strings.TrimRight(a, "/"), strings.TrimRight(b, "/"))
+}
+
+// Version returns the pinned version for a resource kind (fg, fv, model) and name.
+func (p *ProjectFile) Version(kind, name string) int {
+	if p == nil {
+		return 0
+	}
+	return p.Versions[kind][name]
+}
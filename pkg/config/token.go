@@ -0,0 +1,72 @@
+package config
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrTokenExpired means the JWT mounted in the terminal pod has expired and
+// hasn't been rotated; only a new terminal session brings a fresh one.
+var ErrTokenExpired = errors.New("JWT token expired, restart the terminal session")
+
+// TokenPath is the JWT file mounted in Hopsworks terminal pods.
+func TokenPath() string {
+	return filepath.Join(os.Getenv("SECRETS_DIR"), "token.jwt")
+}
+
+// ReadToken reads the in-pod JWT and the file's modification time.
+func ReadToken() (string, time.Time, error) {
+	path := TokenPath()
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return strings.TrimSpace(string(data)), info.ModTime(), nil
+}
+
+// TokenModTime returns the JWT file's modification time (zero if missing).
+func TokenModTime() time.Time {
+	info, err := os.Stat(TokenPath())
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// TokenExpiry returns the exp claim of a JWT. The signature isn't checked.
+func TokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Exp, 0), true
+}
+
+// TokenFileStale reports whether the token currently on disk has expired.
+func TokenFileStale() bool {
+	token, _, err := ReadToken()
+	if err != nil {
+		return false
+	}
+	exp, ok := TokenExpiry(token)
+	return ok && time.Now().After(exp)
+}
@@ -0,0 +1,305 @@
+// Package infer derives Hopsworks feature types from local data files
+// (CSV, JSON, NDJSON, Parquet) without a Python runtime.
+package infer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SampleRows is how many records of a CSV or JSON file are read to infer types.
+const SampleRows = 10000
+
+// Column is one inferred column. Type is a Hopsworks (Hive) type such as
+// bigint, double, boolean, timestamp, string or array<double>.
+type Column struct {
+	Name string
+	Type string
+}
+
+// File infers the columns of a CSV, TSV, JSON (array or single object),
+// NDJSON or Parquet file, picked by extension. Parquet types come from the
+// file footer; the others are inferred from the first SampleRows records.
+func File(path string) ([]Column, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".parquet":
+		return parquetFile(path)
+	case ".csv", ".tsv", ".json", ".ndjson", ".jsonl":
+	default:
+		return nil, fmt.Errorf("unsupported file type %q (use .csv, .tsv, .json, .ndjson or .parquet)", filepath.Ext(path))
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var cols []Column
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		cols, err = CSV(f, ',')
+	case ".tsv":
+		cols, err = CSV(f, '\t')
+	default:
+		cols, err = JSON(f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cols, nil
+}
+
+// CSV infers columns from a delimited file with a header row.
+func CSV(r io.Reader, comma rune) ([]Column, error) {
+	cr := csv.NewReader(bufio.NewReader(r))
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("empty file")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cols := make([]Column, len(header))
+	for i, h := range header {
+		cols[i].Name = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+	}
+	for n := 0; n < SampleRows; n++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i := range cols {
+			if i < len(rec) {
+				cols[i].Type = Merge(cols[i].Type, TextType(rec[i]))
+			}
+		}
+	}
+	return finish(cols), nil
+}
+
+// JSON infers columns from a JSON array of objects, a single object, or
+// newline-delimited objects. Columns keep the order they first appear in.
+func JSON(r io.Reader) ([]Column, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	dec.UseNumber()
+
+	var cols []Column
+	index := map[string]int{}
+	// record reads one object; opened means its '{' was already consumed
+	record := func(opened bool) error {
+		if !opened {
+			if tok, err := dec.Token(); err != nil {
+				return err
+			} else if tok != json.Delim('{') {
+				return fmt.Errorf("expected an object per record, got %v", tok)
+			}
+		}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			var v interface{}
+			if err := dec.Decode(&v); err != nil {
+				return err
+			}
+			name := tok.(string)
+			i, ok := index[name]
+			if !ok {
+				i = len(cols)
+				index[name] = i
+				cols = append(cols, Column{Name: name})
+			}
+			cols[i].Type = Merge(cols[i].Type, ValueType(v))
+		}
+		_, err := dec.Token() // '}'
+		return err
+	}
+
+	first, err := dec.Token()
+	if err == io.EOF {
+		return nil, fmt.Errorf("empty file")
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch first {
+	case json.Delim('['):
+		for n := 0; dec.More() && n < SampleRows; n++ {
+			if err := record(false); err != nil {
+				return nil, err
+			}
+		}
+	case json.Delim('{'):
+		if err := record(true); err != nil {
+			return nil, err
+		}
+		for n := 1; dec.More() && n < SampleRows; n++ {
+			if err := record(false); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("expected a JSON array or objects, got %v", first)
+	}
+	return finish(cols), nil
+}
+
+// TextType returns the type of a value read as text (CSV), or "" for an
+// empty or null-like value.
+func TextType(s string) string {
+	s = strings.TrimSpace(s)
+	switch s {
+	case "", "NA", "N/A", "NaN", "nan", "null", "NULL", "None":
+		return ""
+	}
+	if strings.EqualFold(s, "true") || strings.EqualFold(s, "false") {
+		return "boolean"
+	}
+	if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return "bigint"
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return "double"
+	}
+	if t := timeType(s); t != "" {
+		return t
+	}
+	return "string"
+}
+
+// ValueType returns the type of a decoded JSON value (numbers as
+// json.Number), or "" for null.
+func ValueType(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "bigint"
+		}
+		return "double"
+	case float64:
+		if v == float64(int64(v)) {
+			return "bigint"
+		}
+		return "double"
+	case string:
+		if t := timeType(v); t != "" {
+			return t
+		}
+		return "string"
+	case []interface{}:
+		elem := ""
+		for _, e := range v {
+			elem = Merge(elem, ValueType(e))
+		}
+		return "array<" + elem + ">"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fields := make([]string, len(keys))
+		for i, k := range keys {
+			fields[i] = k + ":" + fill(ValueType(v[k]))
+		}
+		return "struct<" + strings.Join(fields, ",") + ">"
+	}
+	return "string"
+}
+
+var (
+	timestampLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05.999999999",
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05.999999999",
+	}
+	dateLayout = "2006-01-02"
+)
+
+// timeType returns timestamp or date if s parses as one, else "".
+func timeType(s string) string {
+	if len(s) < len(dateLayout) || s[4] != '-' {
+		return ""
+	}
+	if _, err := time.Parse(dateLayout, s); err == nil {
+		return "date"
+	}
+	for _, layout := range timestampLayouts {
+		if _, err := time.Parse(layout, s); err == nil {
+			return "timestamp"
+		}
+	}
+	return ""
+}
+
+// Merge returns the narrowest type holding values of types a and b; "" is
+// unknown (only nulls seen so far).
+func Merge(a, b string) string {
+	switch {
+	case a == "" || a == b:
+		return b
+	case b == "":
+		return a
+	}
+	pair := func(x, y string) bool { return a == x && b == y || a == y && b == x }
+	ea, aArray := ElemType(a)
+	eb, bArray := ElemType(b)
+	switch {
+	case pair("bigint", "double"):
+		return "double"
+	case pair("date", "timestamp"):
+		return "timestamp"
+	case aArray && bArray:
+		return "array<" + Merge(ea, eb) + ">"
+	}
+	return "string"
+}
+
+// ElemType returns the element type of an array<...> type, and false if t
+// isn't one.
+func ElemType(t string) (string, bool) {
+	if !strings.HasPrefix(t, "array<") || !strings.HasSuffix(t, ">") {
+		return "", false
+	}
+	return t[len("array<") : len(t)-1], true
+}
+
+// fill replaces unknown types, top-level or as an array element, with string.
+func fill(t string) string {
+	if t == "" {
+		return "string"
+	}
+	if elem, ok := ElemType(t); ok {
+		return "array<" + fill(elem) + ">"
+	}
+	return t
+}
+
+func finish(cols []Column) []Column {
+	for i := range cols {
+		cols[i].Type = fill(cols[i].Type)
+	}
+	return cols
+}
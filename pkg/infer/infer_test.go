@@ -0,0 +1,117 @@
+package infer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestElemType(t *testing.T) {
+	tests := []struct {
+		in, want string
+		ok       bool
+	}{
+		{"array<double>", "double", true},
+		{"array<array<bigint>>", "array<bigint>", true},
+		{"array<>", "", true},
+		{"array<", "", false},
+		{"array<double", "", false},
+		{"double", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ElemType(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ElemType(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct{ a, b, want string }{
+		{"", "bigint", "bigint"},
+		{"bigint", "", "bigint"},
+		{"bigint", "bigint", "bigint"},
+		{"bigint", "double", "double"},
+		{"double", "bigint", "double"},
+		{"date", "timestamp", "timestamp"},
+		{"bigint", "boolean", "string"},
+		{"array<bigint>", "array<double>", "array<double>"},
+		{"array<>", "array<string>", "array<string>"},
+		{"array<bigint>", "bigint", "string"},
+		{"array<bigint", "array<double>", "string"},
+	}
+	for _, tt := range tests {
+		if got := Merge(tt.a, tt.b); got != tt.want {
+			t.Errorf("Merge(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCSV(t *testing.T) {
+	in := "\ufeffid, Amount ,flag,day,at,note,empty,mixed\n" +
+		"1,2.5,true,2024-01-02,2024-01-02 10:00:00,x,,1\n" +
+		"2,3,FALSE,2024-01-03,2024-01-02T10:00:00Z,NA,NULL,a\n" +
+		"3,,,,,,\n" // short row
+	got, err := CSV(strings.NewReader(in), ',')
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Column{
+		{"id", "bigint"}, {"Amount", "double"}, {"flag", "boolean"}, {"day", "date"},
+		{"at", "timestamp"}, {"note", "string"}, {"empty", "string"}, {"mixed", "string"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %v\nwant %v", got, want)
+	}
+
+	got, err = CSV(strings.NewReader("a\tb\n1\tx\n"), '\t')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Column{{"a", "bigint"}, {"b", "string"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tsv: got %v, want %v", got, want)
+	}
+
+	if _, err := CSV(strings.NewReader(""), ','); err == nil {
+		t.Error("want an error for an empty file")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	want := []Column{
+		{"id", "bigint"}, {"score", "double"}, {"tags", "array<string>"}, {"vec", "array<double>"},
+		{"at", "timestamp"}, {"meta", "struct<n:bigint,x:string>"}, {"none", "string"}, {"late", "boolean"},
+	}
+	records := []string{
+		`{"id": 1, "score": 1, "tags": [], "vec": [1, 2], "at": "2024-01-02T10:00:00Z", "meta": {"n": 1, "x": null}, "none": null}`,
+		`{"id": 2, "score": 1.5, "tags": ["a"], "vec": [0.5], "at": "2024-01-02", "meta": {"n": 2, "x": null}, "late": true}`,
+	}
+	inputs := map[string]string{
+		"array":  "[" + strings.Join(records, ",") + "]",
+		"ndjson": strings.Join(records, "\n") + "\n",
+	}
+	for name, in := range inputs {
+		got, err := JSON(strings.NewReader(in))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got  %v\nwant %v", name, got, want)
+		}
+	}
+
+	got, err := JSON(strings.NewReader(`{"a": "x"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Column{{"a", "string"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("single object: got %v, want %v", got, want)
+	}
+
+	for _, in := range []string{"", "42", "[1, 2]"} {
+		if _, err := JSON(strings.NewReader(in)); err == nil {
+			t.Errorf("JSON(%q): want an error", in)
+		}
+	}
+}
@@ -0,0 +1,608 @@
+package infer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Parquet types are read from the footer: a Thrift (compact protocol)
+// FileMetaData holding the schema as a flattened tree of SchemaElements.
+// Only the fields needed to name a Hive type, count rows and count nulls are
+// decoded; the rest is skipped.
+
+var parquetMagic = []byte("PAR1")
+
+// Physical types
+const (
+	pqBoolean = iota
+	pqInt32
+	pqInt64
+	pqInt96
+	pqFloat
+	pqDouble
+	pqByteArray
+	pqFixedLenByteArray
+)
+
+// Converted (legacy logical) types
+const (
+	pqUTF8            = 0
+	pqMap             = 1
+	pqMapKeyValue     = 2
+	pqList            = 3
+	pqEnum            = 4
+	pqDecimal         = 5
+	pqDate            = 6
+	pqTimestampMillis = 9
+	pqTimestampMicros = 10
+	pqUint8           = 11
+	pqUint16          = 12
+	pqUint32          = 13
+	pqUint64          = 14
+	pqInt8            = 15
+	pqInt16           = 16
+	pqJSON            = 19
+)
+
+const pqRepeated = 2
+
+// schemaElement is the subset of parquet's SchemaElement used here.
+type schemaElement struct {
+	name        string
+	physical    int // -1 for groups
+	repetition  int
+	numChildren int
+	converted   int // -1 if unset
+	scale       int
+	precision   int
+	logical     int // LogicalType union field id, 0 if unset
+	bitWidth    int
+	signed      bool
+	children    []*schemaElement
+}
+
+func parquetFile(path string) ([]Column, error) {
+	meta, err := ReadParquetMeta(path)
+	if err != nil {
+		return nil, err
+	}
+	return meta.Columns, nil
+}
+
+// ParquetMeta is what a Parquet footer says about the file without decoding
+// any data page.
+type ParquetMeta struct {
+	Columns []Column
+	Rows    int64
+	// Nulls holds the null count of each flat top-level column, summed over
+	// row groups. Columns whose writer left out statistics are absent.
+	Nulls map[string]int64
+}
+
+// ReadParquetMeta reads the footer of a Parquet file.
+func ReadParquetMeta(path string) (*ParquetMeta, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	meta, err := parquetMeta(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return meta, nil
+}
+
+// Parquet returns the top-level columns of a Parquet file.
+func Parquet(r io.ReadSeeker) ([]Column, error) {
+	meta, err := parquetMeta(r)
+	if err != nil {
+		return nil, err
+	}
+	return meta.Columns, nil
+}
+
+func parquetMeta(r io.ReadSeeker) (*ParquetMeta, error) {
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if size < 12 {
+		return nil, errors.New("not a parquet file")
+	}
+	tail := make([]byte, 8)
+	if _, err := r.Seek(size-8, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(r, tail); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(tail[4:], parquetMagic) {
+		return nil, errors.New("not a parquet file (bad magic)")
+	}
+	n := int64(binary.LittleEndian.Uint32(tail))
+	if n <= 0 || n > size-12 {
+		return nil, errors.New("corrupt parquet footer")
+	}
+	buf := make([]byte, n)
+	if _, err := r.Seek(size-8-n, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+
+	footer, err := readFileMetaData(&thriftReader{b: buf})
+	if err != nil {
+		return nil, fmt.Errorf("read parquet footer: %w", err)
+	}
+	if len(footer.schema) == 0 {
+		return nil, errors.New("parquet file has no schema")
+	}
+	root, rest := buildTree(footer.schema)
+	if len(rest) > 0 {
+		return nil, errors.New("corrupt parquet schema")
+	}
+
+	meta := &ParquetMeta{Rows: footer.numRows, Nulls: map[string]int64{}}
+	meta.Columns = make([]Column, len(root.children))
+	for i, c := range root.children {
+		meta.Columns[i] = Column{Name: c.name, Type: hiveType(c)}
+		if c.physical < 0 || c.repetition == pqRepeated {
+			continue
+		}
+		if nulls, ok := footer.nulls[c.name]; ok && footer.chunks[c.name] == footer.rowGroups {
+			meta.Nulls[c.name] = nulls
+		}
+	}
+	return meta, nil
+}
+
+// buildTree nests the depth-first element list; it returns the first
+// element with its children and the elements after its subtree.
+func buildTree(elems []*schemaElement) (*schemaElement, []*schemaElement) {
+	e, rest := elems[0], elems[1:]
+	for i := 0; i < e.numChildren && len(rest) > 0; i++ {
+		var child *schemaElement
+		child, rest = buildTree(rest)
+		e.children = append(e.children, child)
+	}
+	return e, rest
+}
+
+// hiveType maps a schema element to the Hive type Hopsworks uses.
+func hiveType(e *schemaElement) string {
+	if e.physical < 0 {
+		return groupType(e)
+	}
+	t := leafType(e)
+	if e.repetition == pqRepeated {
+		return "array<" + t + ">" // legacy unannotated list
+	}
+	return t
+}
+
+func groupType(e *schemaElement) string {
+	switch {
+	case (e.converted == pqList || e.logical == 3) && len(e.children) == 1:
+		item := e.children[0]
+		if item.physical < 0 && len(item.children) == 1 && item.repetition == pqRepeated {
+			// Three-level list: LIST { repeated group list { element } }
+			return "array<" + hiveType(item.children[0]) + ">"
+		}
+		if item.physical < 0 {
+			return "array<" + groupType(item) + ">"
+		}
+		return "array<" + leafType(item) + ">"
+	case (e.converted == pqMap || e.converted == pqMapKeyValue || e.logical == 2) && len(e.children) == 1:
+		kv := e.children[0]
+		if len(kv.children) == 2 {
+			return "map<" + hiveType(kv.children[0]) + "," + hiveType(kv.children[1]) + ">"
+		}
+	}
+	fields := make([]string, len(e.children))
+	for i, c := range e.children {
+		fields[i] = c.name + ":" + hiveType(c)
+	}
+	return "struct<" + strings.Join(fields, ",") + ">"
+}
+
+func leafType(e *schemaElement) string {
+	switch e.logical {
+	case 1, 4, 12, 14: // STRING, ENUM, JSON, UUID
+		return "string"
+	case 5:
+		return fmt.Sprintf("decimal(%d,%d)", e.precision, e.scale)
+	case 6:
+		return "date"
+	case 8:
+		return "timestamp"
+	case 10:
+		return intType(e.bitWidth, e.signed)
+	}
+	switch e.converted {
+	case pqUTF8, pqEnum, pqJSON:
+		return "string"
+	case pqDecimal:
+		return fmt.Sprintf("decimal(%d,%d)", e.precision, e.scale)
+	case pqDate:
+		return "date"
+	case pqTimestampMillis, pqTimestampMicros:
+		return "timestamp"
+	case pqInt8:
+		return "tinyint"
+	case pqInt16:
+		return "smallint"
+	case pqUint8, pqUint16, pqUint32, pqUint64:
+		return intType(map[int]int{pqUint8: 8, pqUint16: 16, pqUint32: 32, pqUint64: 64}[e.converted], false)
+	}
+	switch e.physical {
+	case pqBoolean:
+		return "boolean"
+	case pqInt32:
+		return "int"
+	case pqInt64:
+		return "bigint"
+	case pqInt96:
+		return "timestamp"
+	case pqFloat:
+		return "float"
+	case pqDouble:
+		return "double"
+	}
+	return "binary"
+}
+
+// intType names the narrowest Hive integer holding the given width; unsigned
+// values need the next size up.
+func intType(bits int, signed bool) string {
+	if !signed && bits < 64 {
+		bits *= 2
+	}
+	switch {
+	case bits <= 8:
+		return "tinyint"
+	case bits <= 16:
+		return "smallint"
+	case bits <= 32:
+		return "int"
+	}
+	return "bigint"
+}
+
+// fileMetaData is the subset of parquet's FileMetaData used here: the
+// schema (field 2), num_rows (3) and, from the row groups (4), the null
+// counts of flat columns.
+type fileMetaData struct {
+	schema    []*schemaElement
+	numRows   int64
+	rowGroups int
+	chunks    map[string]int   // row groups with a null count, per column
+	nulls     map[string]int64 // summed null counts, per column
+}
+
+func readFileMetaData(r *thriftReader) (*fileMetaData, error) {
+	m := &fileMetaData{chunks: map[string]int{}, nulls: map[string]int64{}}
+	err := r.fields(func(id int16, t byte) error {
+		switch {
+		case id == 2 && t == tList:
+			n, _, err := r.listHeader()
+			if err != nil {
+				return err
+			}
+			for i := 0; i < n; i++ {
+				e, err := readSchemaElement(r)
+				if err != nil {
+					return err
+				}
+				m.schema = append(m.schema, e)
+			}
+			return nil
+		case id == 3 && t == tI64:
+			var err error
+			m.numRows, err = r.varint()
+			return err
+		case id == 4 && t == tList:
+			n, _, err := r.listHeader()
+			if err != nil {
+				return err
+			}
+			m.rowGroups = n
+			for i := 0; i < n; i++ {
+				if err := readRowGroup(r, m); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return r.skip(t)
+	})
+	return m, err
+}
+
+// readRowGroup reads RowGroup.columns (1): ColumnChunk.meta_data (3) holds
+// path_in_schema (3) and statistics (12), whose null_count is field 3.
+func readRowGroup(r *thriftReader, m *fileMetaData) error {
+	return r.fields(func(id int16, t byte) error {
+		if id != 1 || t != tList {
+			return r.skip(t)
+		}
+		n, _, err := r.listHeader()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			err := r.fields(func(id int16, t byte) error {
+				if id != 3 || t != tStruct {
+					return r.skip(t)
+				}
+				var path []string
+				nulls, hasNulls := int64(0), false
+				err := r.fields(func(id int16, t byte) error {
+					switch {
+					case id == 3 && t == tList:
+						n, _, err := r.listHeader()
+						if err != nil {
+							return err
+						}
+						for j := 0; j < n; j++ {
+							b, err := r.binary()
+							if err != nil {
+								return err
+							}
+							path = append(path, string(b))
+						}
+						return nil
+					case id == 12 && t == tStruct:
+						return r.fields(func(id int16, t byte) error {
+							if id != 3 || t != tI64 {
+								return r.skip(t)
+							}
+							var err error
+							nulls, err = r.varint()
+							hasNulls = true
+							return err
+						})
+					}
+					return r.skip(t)
+				})
+				if err == nil && len(path) == 1 && hasNulls {
+					m.chunks[path[0]]++
+					m.nulls[path[0]] += nulls
+				}
+				return err
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func readSchemaElement(r *thriftReader) (*schemaElement, error) {
+	e := &schemaElement{physical: -1, converted: -1, signed: true}
+	err := r.fields(func(id int16, t byte) error {
+		var err error
+		switch {
+		case id == 1 && t == tI32:
+			e.physical, err = r.int()
+		case id == 3 && t == tI32:
+			e.repetition, err = r.int()
+		case id == 4 && t == tBinary:
+			var b []byte
+			b, err = r.binary()
+			e.name = string(b)
+		case id == 5 && t == tI32:
+			e.numChildren, err = r.int()
+		case id == 6 && t == tI32:
+			e.converted, err = r.int()
+		case id == 7 && t == tI32:
+			e.scale, err = r.int()
+		case id == 8 && t == tI32:
+			e.precision, err = r.int()
+		case id == 10 && t == tStruct:
+			err = readLogicalType(r, e)
+		default:
+			err = r.skip(t)
+		}
+		return err
+	})
+	return e, err
+}
+
+// readLogicalType reads the LogicalType union; the set field's id is the type.
+func readLogicalType(r *thriftReader, e *schemaElement) error {
+	return r.fields(func(id int16, t byte) error {
+		e.logical = int(id)
+		if t != tStruct {
+			return r.skip(t)
+		}
+		return r.fields(func(fid int16, ft byte) error {
+			var err error
+			switch {
+			case id == 5 && fid == 1 && ft == tI32:
+				e.scale, err = r.int()
+			case id == 5 && fid == 2 && ft == tI32:
+				e.precision, err = r.int()
+			case id == 10 && fid == 1 && ft == tByte:
+				var b byte
+				b, err = r.byte()
+				e.bitWidth = int(int8(b))
+			case id == 10 && fid == 2 && (ft == tTrue || ft == tFalse):
+				e.signed = ft == tTrue
+			default:
+				err = r.skip(ft)
+			}
+			return err
+		})
+	})
+}
+
+// Thrift compact protocol type ids
+const (
+	tTrue   = 1
+	tFalse  = 2
+	tByte   = 3
+	tI16    = 4
+	tI32    = 5
+	tI64    = 6
+	tDouble = 7
+	tBinary = 8
+	tList   = 9
+	tSet    = 10
+	tMap    = 11
+	tStruct = 12
+)
+
+var errShortFooter = errors.New("unexpected end of footer")
+
+type thriftReader struct {
+	b   []byte
+	pos int
+}
+
+func (r *thriftReader) byte() (byte, error) {
+	if r.pos >= len(r.b) {
+		return 0, errShortFooter
+	}
+	c := r.b[r.pos]
+	r.pos++
+	return c, nil
+}
+
+func (r *thriftReader) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(r.b[r.pos:])
+	if n <= 0 {
+		return 0, errShortFooter
+	}
+	r.pos += n
+	return v, nil
+}
+
+// varint reads a zigzag-encoded integer (i16, i32, i64).
+func (r *thriftReader) varint() (int64, error) {
+	u, err := r.uvarint()
+	return int64(u>>1) ^ -int64(u&1), err
+}
+
+func (r *thriftReader) int() (int, error) {
+	v, err := r.varint()
+	return int(v), err
+}
+
+func (r *thriftReader) binary() ([]byte, error) {
+	n, err := r.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(r.b)-r.pos) < n {
+		return nil, errShortFooter
+	}
+	b := r.b[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b, nil
+}
+
+// fields calls fn for each field of a struct until its stop byte. fn must
+// consume the value (or skip it); booleans carry theirs in the type id.
+func (r *thriftReader) fields(fn func(id int16, t byte) error) error {
+	var last int16
+	for {
+		h, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if h == 0 {
+			return nil
+		}
+		t := h & 0x0f
+		id := last + int16(h>>4)
+		if h>>4 == 0 {
+			v, err := r.varint()
+			if err != nil {
+				return err
+			}
+			id = int16(v)
+		}
+		last = id
+		if err := fn(id, t); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *thriftReader) listHeader() (int, byte, error) {
+	h, err := r.byte()
+	if err != nil {
+		return 0, 0, err
+	}
+	n := int(h >> 4)
+	if n == 15 {
+		u, err := r.uvarint()
+		if err != nil {
+			return 0, 0, err
+		}
+		n = int(u)
+	}
+	return n, h & 0x0f, nil
+}
+
+// skip consumes a field value of type t.
+func (r *thriftReader) skip(t byte) error {
+	var err error
+	switch t {
+	case tTrue, tFalse:
+	case tByte:
+		_, err = r.byte()
+	case tI16, tI32, tI64:
+		_, err = r.uvarint()
+	case tDouble:
+		if r.pos+8 > len(r.b) {
+			return errShortFooter
+		}
+		r.pos += 8
+	case tBinary:
+		_, err = r.binary()
+	case tList, tSet:
+		n, et, err := r.listHeader()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			if et == tTrue || et == tFalse {
+				et = tByte // list booleans take a byte each
+			}
+			if err := r.skip(et); err != nil {
+				return err
+			}
+		}
+	case tMap:
+		n, err := r.uvarint()
+		if err != nil || n == 0 {
+			return err
+		}
+		kv, err := r.byte()
+		if err != nil {
+			return err
+		}
+		for i := uint64(0); i < n; i++ {
+			if err := r.skip(kv >> 4); err != nil {
+				return err
+			}
+			if err := r.skip(kv & 0x0f); err != nil {
+				return err
+			}
+		}
+	case tStruct:
+		err = r.fields(func(_ int16, ft byte) error { return r.skip(ft) })
+	default:
+		err = fmt.Errorf("unknown thrift type %d", t)
+	}
+	return err
+}
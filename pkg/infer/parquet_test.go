@@ -0,0 +1,110 @@
+package infer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//go:generate go run testdata/genparquet.go
+
+func TestReadParquetMeta(t *testing.T) {
+	tests := []struct {
+		file  string
+		cols  []Column
+		rows  int64
+		nulls map[string]int64
+	}{
+		{
+			// Two row groups; category is dictionary-encoded, score has
+			// statistics in one row group only and legacy_ts in none
+			file: "flat.parquet",
+			cols: []Column{
+				{"id", "bigint"}, {"name", "string"}, {"category", "string"}, {"score", "double"},
+				{"day", "date"}, {"ts", "timestamp"}, {"price", "decimal(10,2)"}, {"flag", "boolean"},
+				{"small", "tinyint"}, {"legacy_ts", "timestamp"},
+			},
+			rows:  6,
+			nulls: map[string]int64{"id": 0, "name": 3, "category": 1, "day": 0, "ts": 2, "price": 0, "flag": 1, "small": 0},
+		},
+		{
+			// Null counts are only kept for flat, non-repeated columns
+			file: "nested.parquet",
+			cols: []Column{
+				{"id", "bigint"}, {"tags", "array<string>"}, {"scores", "array<double>"},
+				{"attrs", "map<string,bigint>"}, {"address", "struct<city:string,zip:int>"},
+				{"matrix", "array<array<double>>"},
+			},
+			rows:  3,
+			nulls: map[string]int64{"id": 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join("testdata", tt.file)
+			meta, err := ReadParquetMeta(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(meta.Columns, tt.cols) {
+				t.Errorf("columns\n got %v\nwant %v", meta.Columns, tt.cols)
+			}
+			if meta.Rows != tt.rows {
+				t.Errorf("rows = %d, want %d", meta.Rows, tt.rows)
+			}
+			if !reflect.DeepEqual(meta.Nulls, tt.nulls) {
+				t.Errorf("nulls\n got %v\nwant %v", meta.Nulls, tt.nulls)
+			}
+
+			cols, err := File(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(cols, tt.cols) {
+				t.Errorf("File: got %v, want %v", cols, tt.cols)
+			}
+		})
+	}
+}
+
+func TestParquetCorrupt(t *testing.T) {
+	good, err := os.ReadFile(filepath.Join("testdata", "flat.parquet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	footerLen := binary.LittleEndian.Uint32(good[len(good)-8:])
+	footerStart := len(good) - 8 - int(footerLen)
+
+	withLen := func(n uint32) []byte {
+		b := bytes.Clone(good)
+		binary.LittleEndian.PutUint32(b[len(b)-8:], n)
+		return b
+	}
+	// The footer cut short, with the length adjusted to match
+	cut := append(bytes.Clone(good[:footerStart+int(footerLen)/2]), good[len(good)-8:]...)
+	binary.LittleEndian.PutUint32(cut[len(cut)-8:], footerLen/2)
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"too small", []byte("PAR1PAR1"), "not a parquet file"},
+		{"bad magic", append(bytes.Clone(good[:len(good)-4]), "PAR0"...), "bad magic"},
+		{"zero footer length", withLen(0), "corrupt parquet footer"},
+		{"footer longer than file", withLen(uint32(len(good))), "corrupt parquet footer"},
+		{"truncated footer", cut, "read parquet footer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parquet(bytes.NewReader(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
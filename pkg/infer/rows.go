@@ -0,0 +1,175 @@
+package infer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Rows reads the records of a CSV, TSV, JSON or NDJSON file one at a time,
+// without loading the file.
+type Rows struct {
+	// Columns are the CSV header, or the keys of the JSON records read so
+	// far in the order they first appeared.
+	Columns []string
+	// Ragged counts CSV records whose field count differs from the header.
+	Ragged int64
+
+	next  func() ([]interface{}, error)
+	close func() error
+}
+
+// OpenRows opens a CSV, TSV, JSON (array or objects) or NDJSON file, picked
+// by extension.
+func OpenRows(path string) (*Rows, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	switch ext {
+	case ".csv", ".tsv", ".json", ".ndjson", ".jsonl":
+	default:
+		return nil, fmt.Errorf("unsupported file type %q (use .csv, .tsv, .json or .ndjson)", filepath.Ext(path))
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &Rows{close: f.Close}
+
+	switch ext {
+	case ".csv", ".tsv":
+		err = r.openCSV(f, map[bool]rune{true: '\t', false: ','}[ext == ".tsv"])
+	default:
+		err = r.openJSON(f)
+	}
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Next returns the next record's values aligned with Columns: strings for
+// CSV, decoded values (numbers as json.Number) for JSON, and nil where the
+// record has no value. It returns io.EOF after the last record.
+func (r *Rows) Next() ([]interface{}, error) {
+	return r.next()
+}
+
+func (r *Rows) Close() error {
+	return r.close()
+}
+
+func (r *Rows) openCSV(f io.Reader, comma rune) error {
+	cr := csv.NewReader(bufio.NewReader(f))
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return fmt.Errorf("empty file")
+	}
+	if err != nil {
+		return err
+	}
+	for _, h := range header {
+		r.Columns = append(r.Columns, strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+	}
+
+	r.next = func() ([]interface{}, error) {
+		rec, err := cr.Read()
+		if err != nil {
+			return nil, err
+		}
+		if len(rec) != len(r.Columns) {
+			r.Ragged++
+		}
+		row := make([]interface{}, len(r.Columns))
+		for i := range row {
+			if i < len(rec) {
+				row[i] = rec[i]
+			}
+		}
+		return row, nil
+	}
+	return nil
+}
+
+func (r *Rows) openJSON(f io.Reader) error {
+	dec := json.NewDecoder(bufio.NewReader(f))
+	dec.UseNumber()
+	index := map[string]int{}
+
+	// record reads one object; opened means its '{' was already consumed
+	record := func(opened bool) ([]interface{}, error) {
+		if !opened {
+			if tok, err := dec.Token(); err != nil {
+				return nil, err
+			} else if tok != json.Delim('{') {
+				return nil, fmt.Errorf("expected an object per record, got %v", tok)
+			}
+		}
+		values := map[int]interface{}{}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			var v interface{}
+			if err := dec.Decode(&v); err != nil {
+				return nil, err
+			}
+			name := tok.(string)
+			i, ok := index[name]
+			if !ok {
+				i = len(r.Columns)
+				index[name] = i
+				r.Columns = append(r.Columns, name)
+			}
+			values[i] = v
+		}
+		if _, err := dec.Token(); err != nil { // '}'
+			return nil, err
+		}
+		row := make([]interface{}, len(r.Columns))
+		for i, v := range values {
+			row[i] = v
+		}
+		return row, nil
+	}
+
+	first, err := dec.Token()
+	if err == io.EOF {
+		return fmt.Errorf("empty file")
+	}
+	if err != nil {
+		return err
+	}
+	switch first {
+	case json.Delim('['):
+		r.next = func() ([]interface{}, error) {
+			if !dec.More() {
+				return nil, io.EOF
+			}
+			return record(false)
+		}
+	case json.Delim('{'):
+		opened := true
+		r.next = func() ([]interface{}, error) {
+			if opened {
+				opened = false
+				return record(true)
+			}
+			if !dec.More() {
+				return nil, io.EOF
+			}
+			return record(false)
+		}
+	default:
+		return fmt.Errorf("expected a JSON array or objects, got %v", first)
+	}
+	return nil
+}
@@ -0,0 +1,556 @@
+//go:build ignore
+
+// genparquet writes the Parquet fixtures of parquet_test.go: uncompressed
+// files with v1 data pages, dictionary pages, statistics and the footer
+// fields a writer like parquet-mr or Arrow emits, so the footer reader is
+// exercised on whole files.
+//
+//	go run testdata/genparquet.go
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log"
+	"math"
+	"math/bits"
+	"os"
+	"path/filepath"
+)
+
+// Physical types, repetitions, converted types and encodings (parquet.thrift)
+const (
+	tBoolean   = 0
+	tInt32     = 1
+	tInt64     = 2
+	tInt96     = 3
+	tDouble    = 5
+	tByteArray = 6
+
+	required = 0
+	optional = 1
+	repeated = 2
+
+	convUTF8            = 0
+	convMap             = 1
+	convList            = 3
+	convDecimal         = 5
+	convDate            = 6
+	convTimestampMicros = 10
+	convInt8            = 15
+
+	encPlain         = 0
+	encRLE           = 3
+	encRLEDictionary = 8
+)
+
+// Thrift compact protocol
+const (
+	ctTrue   = 1
+	ctFalse  = 2
+	ctByte   = 3
+	ctI16    = 4
+	ctI32    = 5
+	ctI64    = 6
+	ctBinary = 8
+	ctList   = 9
+	ctStruct = 12
+)
+
+type thrift struct {
+	bytes.Buffer
+	last []int16 // last field id per open struct
+}
+
+func (w *thrift) uvarint(v uint64) { w.Write(binary.AppendUvarint(nil, v)) }
+func (w *thrift) varint(v int64)   { w.uvarint(uint64(v<<1) ^ uint64(v>>63)) }
+
+func (w *thrift) field(id int16, t byte) {
+	last := &w.last[len(w.last)-1]
+	if d := id - *last; d > 0 && d <= 15 {
+		w.WriteByte(byte(d)<<4 | t)
+	} else {
+		w.WriteByte(t)
+		w.varint(int64(id))
+	}
+	*last = id
+}
+
+func (w *thrift) begin() { w.last = append(w.last, 0) }
+func (w *thrift) end()   { w.WriteByte(0); w.last = w.last[:len(w.last)-1] }
+
+func (w *thrift) i32(id int16, v int) { w.field(id, ctI32); w.varint(int64(v)) }
+func (w *thrift) i64(id int16, v int) { w.field(id, ctI64); w.varint(int64(v)) }
+func (w *thrift) str(id int16, s string) {
+	w.field(id, ctBinary)
+	w.uvarint(uint64(len(s)))
+	w.WriteString(s)
+}
+func (w *thrift) boolean(id int16, v bool) {
+	if v {
+		w.field(id, ctTrue)
+	} else {
+		w.field(id, ctFalse)
+	}
+}
+func (w *thrift) list(id int16, t byte, n int) {
+	w.field(id, ctList)
+	if n < 15 {
+		w.WriteByte(byte(n)<<4 | t)
+	} else {
+		w.WriteByte(0xf0 | t)
+		w.uvarint(uint64(n))
+	}
+}
+func (w *thrift) structField(id int16) { w.field(id, ctStruct); w.begin() }
+
+// node is a schema element; leaves carry their column data.
+type node struct {
+	name       string
+	physical   int // -1 for groups
+	repetition int
+	converted  int // -1 if unset
+	scale      int
+	precision  int
+	logical    func(w *thrift) // writes the LogicalType union, nil if unset
+	children   []*node
+}
+
+func group(name string, rep, conv int, logical func(*thrift), children ...*node) *node {
+	return &node{name: name, physical: -1, repetition: rep, converted: conv, logical: logical, children: children}
+}
+
+func leaf(name string, physical, rep int) *node {
+	return &node{name: name, physical: physical, repetition: rep, converted: -1}
+}
+
+func (n *node) conv(c int) *node            { n.converted = c; return n }
+func (n *node) logic(l func(*thrift)) *node { n.logical = l; return n }
+func (n *node) decimal(precision, scale int) *node {
+	n.converted, n.precision, n.scale = convDecimal, precision, scale
+	n.logical = func(w *thrift) {
+		w.structField(5)
+		w.i32(1, scale)
+		w.i32(2, precision)
+		w.end()
+	}
+	return n
+}
+
+func logicalEmpty(id int16) func(*thrift) {
+	return func(w *thrift) { w.structField(id); w.end() }
+}
+
+var (
+	logicalString = logicalEmpty(1)
+	logicalMap    = logicalEmpty(2)
+	logicalList   = logicalEmpty(3)
+	logicalDate   = logicalEmpty(6)
+)
+
+func logicalTimestampMicros(w *thrift) {
+	w.structField(8)
+	w.boolean(1, true) // isAdjustedToUTC
+	w.structField(2)   // unit: TimeUnit union
+	w.structField(2)   // MICROS
+	w.end()
+	w.end()
+	w.end()
+}
+
+func logicalInt(bitWidth int, signed bool) func(*thrift) {
+	return func(w *thrift) {
+		w.structField(10)
+		w.field(1, ctByte)
+		w.WriteByte(byte(bitWidth))
+		w.boolean(2, signed)
+		w.end()
+	}
+}
+
+// chunk is one leaf column's data in a row group.
+type chunk struct {
+	rep, def []int    // levels, one per value slot
+	values   [][]byte // plain-encoded non-null values
+	bools    []bool   // for BOOLEAN columns instead of values
+	dict     bool     // dictionary-encode
+	stats    bool     // write statistics
+}
+
+type column struct {
+	path   []string
+	node   *node
+	maxRep int
+	maxDef int
+}
+
+// columns lists the leaves depth-first with their max levels.
+func columns(n *node, path []string, rep, def int) []column {
+	if n.repetition == repeated {
+		rep++
+	}
+	if n.repetition != required {
+		def++
+	}
+	path = append(append([]string{}, path...), n.name)
+	if n.physical >= 0 {
+		return []column{{path: path, node: n, maxRep: rep, maxDef: def}}
+	}
+	var cols []column
+	for _, c := range n.children {
+		cols = append(cols, columns(c, path, rep, def)...)
+	}
+	return cols
+}
+
+// rle encodes levels or dictionary indices as RLE runs of the hybrid encoding.
+func rle(vals []int, width int) []byte {
+	var b []byte
+	for i := 0; i < len(vals); {
+		j := i
+		for j < len(vals) && vals[j] == vals[i] {
+			j++
+		}
+		b = binary.AppendUvarint(b, uint64(j-i)<<1)
+		for k := 0; k < (width+7)/8; k++ {
+			b = append(b, byte(vals[i]>>(8*k)))
+		}
+		i = j
+	}
+	return b
+}
+
+func levels(vals []int, max int) []byte {
+	if max == 0 {
+		return nil
+	}
+	enc := rle(vals, bits.Len(uint(max)))
+	return append(binary.LittleEndian.AppendUint32(nil, uint32(len(enc))), enc...)
+}
+
+func pageHeader(pageType, size int, body func(w *thrift)) []byte {
+	w := &thrift{}
+	w.begin()
+	w.i32(1, pageType)
+	w.i32(2, size)
+	w.i32(3, size)
+	body(w)
+	w.end()
+	return w.Bytes()
+}
+
+type chunkMeta struct {
+	col        column
+	numValues  int
+	nulls      int
+	stats      bool
+	dataOffset int
+	dictOffset int // -1 without a dictionary page
+	size       int
+	dict       bool
+}
+
+// writeChunk appends the pages of one column chunk to f.
+func writeChunk(f *bytes.Buffer, col column, c chunk) chunkMeta {
+	m := chunkMeta{col: col, numValues: len(c.def), dictOffset: -1, stats: c.stats, dict: c.dict}
+	if col.maxDef == 0 {
+		m.numValues = len(c.values)
+	}
+	for _, d := range c.def {
+		if d < col.maxDef {
+			m.nulls++
+		}
+	}
+	start := f.Len()
+
+	var values []byte
+	switch {
+	case col.node.physical == tBoolean:
+		packed := make([]byte, (len(c.bools)+7)/8)
+		for i, v := range c.bools {
+			if v {
+				packed[i/8] |= 1 << (i % 8)
+			}
+		}
+		values = packed
+	case c.dict:
+		var dict [][]byte
+		index := map[string]int{}
+		ids := make([]int, len(c.values))
+		for i, v := range c.values {
+			id, ok := index[string(v)]
+			if !ok {
+				id = len(dict)
+				index[string(v)] = id
+				dict = append(dict, v)
+			}
+			ids[i] = id
+		}
+		page := bytes.Join(dict, nil)
+		m.dictOffset = f.Len()
+		f.Write(pageHeader(2, len(page), func(w *thrift) {
+			w.structField(7)
+			w.i32(1, len(dict))
+			w.i32(2, encPlain)
+			w.end()
+		}))
+		f.Write(page)
+		width := bits.Len(uint(len(dict) - 1))
+		values = append([]byte{byte(width)}, rle(ids, width)...)
+	default:
+		values = bytes.Join(c.values, nil)
+	}
+
+	page := append(append(levels(c.rep, col.maxRep), levels(c.def, col.maxDef)...), values...)
+	encoding := encPlain
+	if c.dict {
+		encoding = encRLEDictionary
+	}
+	m.dataOffset = f.Len()
+	f.Write(pageHeader(0, len(page), func(w *thrift) {
+		w.structField(5)
+		w.i32(1, m.numValues)
+		w.i32(2, encoding)
+		w.i32(3, encRLE)
+		w.i32(4, encRLE)
+		w.end()
+	}))
+	f.Write(page)
+	m.size = f.Len() - start
+	return m
+}
+
+func writeSchema(w *thrift, n *node, root bool) {
+	w.begin()
+	if n.physical >= 0 {
+		w.i32(1, n.physical)
+	}
+	if !root {
+		w.i32(3, n.repetition)
+	}
+	w.str(4, n.name)
+	if n.physical < 0 {
+		w.i32(5, len(n.children))
+	}
+	if n.converted >= 0 {
+		w.i32(6, n.converted)
+	}
+	if n.converted == convDecimal {
+		w.i32(7, n.scale)
+		w.i32(8, n.precision)
+	}
+	if n.logical != nil {
+		w.structField(10)
+		n.logical(w)
+		w.end()
+	}
+	w.end()
+	for _, c := range n.children {
+		writeSchema(w, c, false)
+	}
+}
+
+func countNodes(n *node) int {
+	count := 1
+	for _, c := range n.children {
+		count += countNodes(c)
+	}
+	return count
+}
+
+// writeFile writes a Parquet file of the schema under root with row groups
+// of numRows[i] rows, whose chunks are given per leaf column in order.
+func writeFile(path string, root *node, numRows []int, groups [][]chunk) {
+	cols := []column{}
+	for _, c := range root.children {
+		cols = append(cols, columns(c, nil, 0, 0)...)
+	}
+	f := &bytes.Buffer{}
+	f.WriteString("PAR1")
+	var metas [][]chunkMeta
+	for _, chunks := range groups {
+		if len(chunks) != len(cols) {
+			log.Fatalf("%s: %d chunks for %d columns", path, len(chunks), len(cols))
+		}
+		var ms []chunkMeta
+		for i, c := range chunks {
+			ms = append(ms, writeChunk(f, cols[i], c))
+		}
+		metas = append(metas, ms)
+	}
+
+	total := 0
+	for _, n := range numRows {
+		total += n
+	}
+	w := &thrift{}
+	w.begin()
+	w.i32(1, 1) // version
+	w.list(2, ctStruct, countNodes(root))
+	writeSchema(w, root, true)
+	w.i64(3, total)
+	w.list(4, ctStruct, len(metas))
+	for g, ms := range metas {
+		w.begin()
+		w.list(1, ctStruct, len(ms))
+		size := 0
+		for _, m := range ms {
+			size += m.size
+			w.begin()
+			w.i64(2, m.dataOffset+m.size)
+			w.structField(3)
+			w.i32(1, m.col.node.physical)
+			if m.dict {
+				w.list(2, ctI32, 3)
+				w.varint(encPlain)
+				w.varint(encRLE)
+				w.varint(encRLEDictionary)
+			} else {
+				w.list(2, ctI32, 2)
+				w.varint(encPlain)
+				w.varint(encRLE)
+			}
+			w.list(3, ctBinary, len(m.col.path))
+			for _, p := range m.col.path {
+				w.uvarint(uint64(len(p)))
+				w.WriteString(p)
+			}
+			w.i32(4, 0) // UNCOMPRESSED
+			w.i64(5, m.numValues)
+			w.i64(6, m.size)
+			w.i64(7, m.size)
+			w.i64(9, m.dataOffset)
+			if m.dictOffset >= 0 {
+				w.i64(11, m.dictOffset)
+			}
+			if m.stats {
+				w.structField(12)
+				w.i64(3, m.nulls)
+				w.end()
+			}
+			w.end()
+			w.end()
+		}
+		w.i64(2, size)
+		w.i64(3, numRows[g])
+		w.i64(5, ms[0].dataOffset)
+		w.i64(6, size)
+		w.field(7, ctI16)
+		w.varint(int64(g))
+		w.end()
+	}
+	w.list(5, ctStruct, 1)
+	w.begin()
+	w.str(1, "writer.note")
+	w.str(2, "hopsworks-cli test fixture")
+	w.end()
+	w.str(6, "genparquet (hopsworks-cli testdata)")
+	w.list(7, ctStruct, len(cols))
+	for range cols {
+		w.begin()
+		w.structField(1) // TYPE_ORDER
+		w.end()
+		w.end()
+	}
+	w.end()
+
+	f.Write(w.Bytes())
+	f.Write(binary.LittleEndian.AppendUint32(nil, uint32(w.Len())))
+	f.WriteString("PAR1")
+	if err := os.WriteFile(path, f.Bytes(), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func i32(v int) []byte { return binary.LittleEndian.AppendUint32(nil, uint32(int32(v))) }
+func i64(v int) []byte { return binary.LittleEndian.AppendUint64(nil, uint64(int64(v))) }
+func f64(v float64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, math.Float64bits(v))
+}
+func str(s string) []byte { return append(i32(len(s)), s...) }
+func i96(v int) []byte    { return append(i64(v), i32(2460000)...) } // nanos of day, Julian day
+
+func vals(vs ...[]byte) [][]byte { return vs }
+
+func main() {
+	dir := "testdata"
+	if _, err := os.Stat(dir); err != nil {
+		dir = "."
+	}
+
+	// flat.parquet: 2 row groups of 3 rows. category is dictionary-encoded;
+	// score has statistics in the first row group only, legacy_ts in none.
+	flat := group("schema", required, -1, nil,
+		leaf("id", tInt64, required),
+		leaf("name", tByteArray, optional).conv(convUTF8).logic(logicalString),
+		leaf("category", tByteArray, optional).conv(convUTF8).logic(logicalString),
+		leaf("score", tDouble, optional),
+		leaf("day", tInt32, optional).conv(convDate).logic(logicalDate),
+		leaf("ts", tInt64, optional).conv(convTimestampMicros).logic(logicalTimestampMicros),
+		leaf("price", tInt64, optional).decimal(10, 2),
+		leaf("flag", tBoolean, optional),
+		leaf("small", tInt32, required).conv(convInt8).logic(logicalInt(8, true)),
+		leaf("legacy_ts", tInt96, optional),
+	)
+	writeFile(filepath.Join(dir, "flat.parquet"), flat, []int{3, 3}, [][]chunk{
+		{
+			{values: vals(i64(1), i64(2), i64(3)), stats: true},
+			{def: []int{1, 0, 1}, values: vals(str("ana"), str("bo")), stats: true},
+			{def: []int{1, 1, 1}, values: vals(str("web"), str("store"), str("web")), dict: true, stats: true},
+			{def: []int{1, 1, 0}, values: vals(f64(1.5), f64(2)), stats: true},
+			{def: []int{1, 1, 1}, values: vals(i32(19723), i32(19724), i32(19725)), stats: true},
+			{def: []int{1, 0, 0}, values: vals(i64(1704067200000000)), stats: true},
+			{def: []int{1, 1, 1}, values: vals(i64(1999), i64(250), i64(100)), stats: true},
+			{def: []int{1, 1, 0}, bools: []bool{true, false}, stats: true},
+			{values: vals(i32(1), i32(-2), i32(3)), stats: true},
+			{def: []int{1, 1, 1}, values: vals(i96(1), i96(2), i96(3))},
+		},
+		{
+			{values: vals(i64(4), i64(5), i64(6)), stats: true},
+			{def: []int{0, 0, 1}, values: vals(str("cy")), stats: true},
+			{def: []int{1, 0, 1}, values: vals(str("app"), str("web")), dict: true, stats: true},
+			{def: []int{0, 1, 1}, values: vals(f64(3), f64(4.25))},
+			{def: []int{1, 1, 1}, values: vals(i32(19726), i32(19727), i32(19728)), stats: true},
+			{def: []int{1, 1, 1}, values: vals(i64(1704067200000001), i64(1704067200000002), i64(1704067200000003)), stats: true},
+			{def: []int{1, 1, 1}, values: vals(i64(1), i64(2), i64(3)), stats: true},
+			{def: []int{1, 1, 1}, bools: []bool{true, true, false}, stats: true},
+			{values: vals(i32(4), i32(5), i32(6)), stats: true},
+			{def: []int{0, 1, 1}, values: vals(i96(4), i96(5))},
+		},
+	})
+
+	// nested.parquet: 3 rows of
+	//   {1, ["a","b"], [1.5], {"x":1}, {"Oslo",150}, [[1,2],[3]]}
+	//   {2, null, [], {}, null, []}
+	//   {3, ["c",null], [2,3], {"y":null}, {null,7}, null}
+	element := func(n *node) *node { n.name = "element"; return n }
+	nested := group("schema", required, -1, nil,
+		leaf("id", tInt64, required),
+		group("tags", optional, convList, logicalList,
+			group("list", repeated, -1, nil,
+				element(leaf("", tByteArray, optional).conv(convUTF8).logic(logicalString)))),
+		leaf("scores", tDouble, repeated),
+		group("attrs", optional, convMap, logicalMap,
+			group("key_value", repeated, -1, nil,
+				leaf("key", tByteArray, required).conv(convUTF8).logic(logicalString),
+				leaf("value", tInt64, optional))),
+		group("address", optional, -1, nil,
+			leaf("city", tByteArray, optional).conv(convUTF8).logic(logicalString),
+			leaf("zip", tInt32, optional)),
+		group("matrix", optional, convList, logicalList,
+			group("list", repeated, -1, nil,
+				element(group("", optional, convList, logicalList,
+					group("list", repeated, -1, nil,
+						element(leaf("", tDouble, optional))))))),
+	)
+	writeFile(filepath.Join(dir, "nested.parquet"), nested, []int{3}, [][]chunk{{
+		{values: vals(i64(1), i64(2), i64(3)), stats: true},
+		{rep: []int{0, 1, 0, 0, 1}, def: []int{3, 3, 0, 3, 2}, values: vals(str("a"), str("b"), str("c")), dict: true, stats: true},
+		{rep: []int{0, 0, 0, 1}, def: []int{1, 0, 1, 1}, values: vals(f64(1.5), f64(2), f64(3)), stats: true},
+		{rep: []int{0, 0, 0}, def: []int{2, 1, 2}, values: vals(str("x"), str("y")), stats: true},
+		{rep: []int{0, 0, 0}, def: []int{3, 1, 2}, values: vals(i64(1)), stats: true},
+		{rep: []int{}, def: []int{2, 0, 1}, values: vals(str("Oslo")), stats: true},
+		{rep: []int{}, def: []int{2, 0, 2}, values: vals(i32(150), i32(7)), stats: true},
+		{rep: []int{0, 2, 1, 0, 0}, def: []int{5, 5, 5, 1, 0}, values: vals(f64(1), f64(2), f64(3)), stats: true},
+	}})
+}
@@ -0,0 +1,249 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// Column is one column of a list table.
+type Column[T any] struct {
+	Name  string              // header; lowercased, the key for --columns and --sort-by
+	Value func(T) interface{} // typed cell (see Row)
+	Wide  bool                // only shown with --wide, or when named in --columns
+	Flex  bool                // free text, shortened to fit the terminal
+}
+
+// ListOptions select and order list columns (--columns, --sort-by, --wide).
+type ListOptions struct {
+	Columns []string
+	SortBy  string // column key; a leading '-' sorts descending
+	Wide    bool
+}
+
+// minFlexWidth is how far a flex column may shrink to fit the terminal.
+const minFlexWidth = 12
+
+// PrintList sorts items and prints them: whole objects in structured formats,
+// otherwise the selected columns as a table. Options are validated before
+// anything is printed.
+func PrintList[T any](items []T, cols []Column[T], opts ListOptions) error {
+	selected, err := selectColumns(cols, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.SortBy != "" {
+		key, desc := strings.CutPrefix(opts.SortBy, "-")
+		col, err := findColumn(cols, key)
+		if err != nil {
+			return fmt.Errorf("--sort-by: %w", err)
+		}
+		sort.SliceStable(items, func(i, j int) bool {
+			c := compareCells(col.Value(items[i]), col.Value(items[j]))
+			if desc {
+				return c > 0
+			}
+			return c < 0
+		})
+	}
+
+	if Structured() {
+		PrintJSON(items)
+		return nil
+	}
+
+	headers := make([]string, len(selected))
+	flex := make([]bool, len(selected))
+	for i, col := range selected {
+		headers[i] = col.Name
+		flex[i] = col.Flex
+	}
+	rows := make([]Row, 0, len(items))
+	for _, item := range items {
+		row := make(Row, len(selected))
+		for i, col := range selected {
+			row[i] = col.Value(item)
+		}
+		rows = append(rows, row)
+	}
+	table(headers, rows, flex)
+	return nil
+}
+
+func selectColumns[T any](cols []Column[T], opts ListOptions) ([]Column[T], error) {
+	var selected []Column[T]
+	if len(opts.Columns) > 0 {
+		for _, name := range opts.Columns {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			col, err := findColumn(cols, name)
+			if err != nil {
+				return nil, fmt.Errorf("--columns: %w", err)
+			}
+			selected = append(selected, col)
+		}
+		return selected, nil
+	}
+	for _, col := range cols {
+		if !col.Wide || opts.Wide {
+			selected = append(selected, col)
+		}
+	}
+	return selected, nil
+}
+
+func findColumn[T any](cols []Column[T], name string) (Column[T], error) {
+	keys := make([]string, len(cols))
+	for i, col := range cols {
+		keys[i] = strings.ToLower(col.Name)
+		if strings.EqualFold(col.Name, name) {
+			return col, nil
+		}
+	}
+	return Column[T]{}, fmt.Errorf("unknown column %q (available: %s)", name, strings.Join(keys, ", "))
+}
+
+// compareCells orders typed cells: numbers numerically, times chronologically,
+// strings case-insensitively. Empty cells sort last.
+func compareCells(a, b interface{}) int {
+	if a == nil || b == nil || a == "" || b == "" {
+		aEmpty, bEmpty := a == nil || a == "", b == nil || b == ""
+		switch {
+		case aEmpty && bEmpty:
+			return 0
+		case aEmpty:
+			return 1
+		case bEmpty:
+			return -1
+		}
+	}
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	if x, ok := a.(time.Time); ok {
+		if y, ok := b.(time.Time); ok {
+			return x.Compare(y)
+		}
+	}
+	if x, ok := a.(bool); ok {
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0
+			case !x:
+				return -1
+			}
+			return 1
+		}
+	}
+	x, y := formatCell(a), formatCell(b)
+	if c := strings.Compare(strings.ToLower(x), strings.ToLower(y)); c != 0 {
+		return c
+	}
+	return strings.Compare(x, y)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch t := v.(type) {
+	case int:
+		return float64(t), true
+	case int64:
+		return float64(t), true
+	case float64:
+		return t, true
+	case float32:
+		return float64(t), true
+	case json.Number:
+		f, err := t.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// terminalWidth is the width of stdout when it is a terminal ($COLUMNS wins),
+// or 0 when output is piped and nothing should be cut.
+func terminalWidth() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		return w
+	}
+	return 0
+}
+
+// fitWidth shortens flex columns, widest first, until the table fits width.
+func fitWidth(headers []string, rows [][]string, flex []bool, width int) {
+	if width <= 0 {
+		return
+	}
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+			}
+		}
+	}
+
+	total := 2 * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+	for total > width {
+		widest := -1
+		for i, w := range widths {
+			floor := max(minFlexWidth, utf8.RuneCountInString(headers[i]))
+			if flex[i] && w > floor && (widest < 0 || w > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		floor := max(minFlexWidth, utf8.RuneCountInString(headers[widest]))
+		cut := min(total-width, widths[widest]-floor)
+		widths[widest] -= cut
+		total -= cut
+	}
+
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(flex) && flex[i] {
+				row[i] = shorten(cell, widths[i])
+			}
+		}
+	}
+}
+
+// shorten cuts s to n runes, ending in "...".
+func shorten(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	r := []rune(s)
+	if n <= 3 {
+		return string(r[:n])
+	}
+	return string(r[:n-3]) + "..."
+}
@@ -0,0 +1,116 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// APIVersion versions the JSON/YAML envelope. Bump it when a payload changes
+// incompatibly; adding fields is not a breaking change.
+const APIVersion = "hops/v1"
+
+// Envelope wraps every json and yaml document: a list in Items (kind
+// "<Type>List"), a single object in Item.
+type Envelope struct {
+	APIVersion string      `json:"apiVersion"`
+	Kind       string      `json:"kind"`
+	Items      interface{} `json:"items,omitempty"`
+	Item       interface{} `json:"item,omitempty"`
+	Warnings   []string    `json:"warnings,omitempty"`
+}
+
+// pendingWarnings are held for the envelope until something is printed.
+var (
+	pendingWarnings []string
+	printed         bool
+)
+
+// Warn reports a non-fatal problem. In json/yaml output it goes into the
+// envelope's warnings; otherwise it is printed to stderr.
+func Warn(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if enveloped() && !printed {
+		pendingWarnings = append(pendingWarnings, msg)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Warning: %s\n", msg)
+}
+
+// FlushWarnings prints warnings that never made it into an envelope, e.g.
+// because the command failed first.
+func FlushWarnings() {
+	for _, msg := range pendingWarnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", msg)
+	}
+	pendingWarnings = nil
+}
+
+// enveloped reports whether the active format wraps data in an Envelope.
+func enveloped() bool {
+	return Format == FormatJSON || Format == FormatYAML
+}
+
+// PrintKind is PrintJSON with an explicit kind, for payloads whose Go type
+// has no name (decoded rows, maps). Lists get the "List" suffix added.
+func PrintKind(kind string, v interface{}) {
+	if err := render(os.Stdout, wrap(kind, v)); err != nil {
+		fail(err)
+	}
+}
+
+// wrap puts v in an Envelope when the format calls for one.
+func wrap(kind string, v interface{}) interface{} {
+	if !enveloped() {
+		return v
+	}
+	printed = true
+	env := Envelope{APIVersion: APIVersion, Kind: kind, Warnings: pendingWarnings}
+	pendingWarnings = nil
+
+	rv := reflect.ValueOf(v)
+	if raw, ok := v.(json.RawMessage); ok {
+		// Decoded script output: a list only if the document is an array
+		if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+			env.Kind += "List"
+			env.Items = raw
+		} else {
+			env.Item = raw
+		}
+	} else if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		env.Kind += "List"
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			v = []interface{}{}
+		}
+		env.Items = v
+	} else {
+		env.Item = v
+	}
+	return env
+}
+
+// KindOf names the payload after its Go type: client.FeatureGroup and
+// []client.FeatureGroup are both "FeatureGroup" (PrintKind adds "List").
+func KindOf(v interface{}) string {
+	return kindOfType(reflect.TypeOf(v))
+}
+
+func kindOfType(t reflect.Type) string {
+	if t == nil {
+		return "Result"
+	}
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	name := t.Name()
+	if name == "" || t.PkgPath() == "" {
+		return "Result"
+	}
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return strings.TrimSuffix(string(r), "DTO")
+}
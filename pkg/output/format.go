@@ -0,0 +1,366 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats for -o/--output
+const (
+	FormatTable    = "table"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatCSV      = "csv"
+	FormatTSV      = "tsv"
+	FormatNDJSON   = "ndjson"
+	FormatMarkdown = "markdown"
+)
+
+// Formats lists the accepted -o values.
+var Formats = []string{FormatTable, FormatJSON, FormatYAML, FormatCSV, FormatTSV, FormatNDJSON, FormatMarkdown}
+
+// Format is the active output format. JSONMode is true for every format but
+// table: decorations (Success/Info) are suppressed and commands hand their
+// data to PrintJSON, which renders it in Format.
+var Format = FormatTable
+
+// SetFormat validates and activates an output format. "jsonpath=<expr>",
+// "go-template=<tmpl>" and their -file variants are parsed here, so a bad
+// template is reported before the command runs.
+func SetFormat(f string) error {
+	f = strings.TrimSpace(f)
+	if name, arg, ok := strings.Cut(f, "="); ok {
+		if err := setTemplate(strings.ToLower(name), arg); err != nil {
+			return err
+		}
+		JSONMode = true
+		return nil
+	}
+
+	f = strings.ToLower(f)
+	if f == "md" {
+		f = FormatMarkdown
+	}
+	for _, known := range Formats {
+		if f == known {
+			Format = f
+			JSONMode = f != FormatTable
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown output format %q (want %s, jsonpath=<expr> or go-template=<tmpl>)", f, strings.Join(Formats, "|"))
+}
+
+// Structured reports whether the format works on whole objects (json, yaml,
+// ndjson, templates) rather than rows. List commands print their raw items
+// then, and fall back to their table columns for csv/tsv/markdown.
+func Structured() bool {
+	switch Format {
+	case FormatJSON, FormatYAML, FormatNDJSON, FormatJSONPath, FormatGoTemplate:
+		return true
+	}
+	return false
+}
+
+// Row is one table record. Cells keep their Go types (int, float64, bool,
+// time.Time, string, nil) so structured formats don't stringify numbers.
+type Row []interface{}
+
+// formatCell renders a cell for table, csv, tsv and markdown output.
+func formatCell(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case bool:
+		return strconv.FormatBool(t)
+	case int:
+		return strconv.Itoa(t)
+	case int64:
+		return strconv.FormatInt(t, 10)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(t), 'f', -1, 32)
+	case time.Time:
+		if t.IsZero() {
+			return ""
+		}
+		if Format == FormatCSV || Format == FormatTSV {
+			return t.Format(time.RFC3339)
+		}
+		return t.Format("2006-01-02 15:04:05")
+	case json.Number:
+		return t.String()
+	case fmt.Stringer:
+		return t.String()
+	case orderedMap, []interface{}:
+		data, _ := json.Marshal(t)
+		return string(data)
+	}
+	return fmt.Sprint(v)
+}
+
+// writeRows renders headers and string rows as csv, tsv or markdown.
+func writeRows(w io.Writer, headers []string, rows [][]string) error {
+	switch Format {
+	case FormatMarkdown:
+		esc := func(s string) string {
+			return strings.ReplaceAll(strings.ReplaceAll(s, "|", `\|`), "\n", " ")
+		}
+		line := func(cells []string) {
+			for i := range cells {
+				cells[i] = esc(cells[i])
+			}
+			fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+		}
+		line(append([]string(nil), headers...))
+		sep := make([]string, len(headers))
+		for i := range sep {
+			sep[i] = "---"
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(sep, " | "))
+		for _, r := range rows {
+			line(r)
+		}
+		return nil
+	default:
+		cw := csv.NewWriter(w)
+		if Format == FormatTSV {
+			cw.Comma = '\t'
+		}
+		cw.Write(headers)
+		for _, r := range rows {
+			cw.Write(r)
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+}
+
+// render prints v in the active structured or row format.
+func render(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	switch Format {
+	case FormatJSON, FormatTable:
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+		_, err := w.Write(buf.Bytes())
+		return err
+	}
+
+	doc, err := decodeOrdered(data)
+	if err != nil {
+		return err
+	}
+
+	switch Format {
+	case FormatJSONPath:
+		return jsonPath.execute(w, doc)
+	case FormatGoTemplate:
+		return executeGoTemplate(w, data)
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(toYAMLNode(doc)); err != nil {
+			return err
+		}
+		return enc.Close()
+	case FormatNDJSON:
+		items, ok := doc.([]interface{})
+		if !ok {
+			items = []interface{}{doc}
+		}
+		for _, item := range items {
+			line, err := json.Marshal(item)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(w, string(line))
+		}
+		return nil
+	default:
+		headers, rows := flatten(doc)
+		if len(headers) == 0 {
+			return nil
+		}
+		return writeRows(w, headers, rows)
+	}
+}
+
+// flatten turns a decoded document into columns: an array of objects becomes
+// one row per element, an object a single row, anything else a "value" column.
+// Nested values are written as compact JSON.
+func flatten(doc interface{}) ([]string, [][]string) {
+	items, ok := doc.([]interface{})
+	if !ok {
+		items = []interface{}{doc}
+	}
+
+	var headers []string
+	seen := map[string]bool{}
+	for _, item := range items {
+		if m, ok := item.(orderedMap); ok {
+			for _, k := range m.keys {
+				if !seen[k] {
+					seen[k] = true
+					headers = append(headers, k)
+				}
+			}
+		}
+	}
+	if len(headers) == 0 {
+		if len(items) == 0 {
+			return nil, nil
+		}
+		if _, ok := items[0].(orderedMap); ok {
+			return nil, nil
+		}
+		var rows [][]string
+		for _, item := range items {
+			rows = append(rows, []string{formatCell(item)})
+		}
+		return []string{"value"}, rows
+	}
+
+	var rows [][]string
+	for _, item := range items {
+		m, _ := item.(orderedMap)
+		row := make([]string, len(headers))
+		for i, h := range headers {
+			row[i] = formatCell(m.values[h])
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows
+}
+
+// orderedMap is a JSON object that remembers key order, so yaml and csv keep
+// the field order of the Go structs.
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (m orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		buf.Write(key)
+		buf.WriteByte(':')
+		val, err := json.Marshal(m.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeOrdered decodes JSON keeping object key order and exact numbers.
+func decodeOrdered(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeValue(dec)
+}
+
+func decodeValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			m := orderedMap{values: map[string]interface{}{}}
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key := keyTok.(string)
+				val, err := decodeValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				if _, dup := m.values[key]; !dup {
+					m.keys = append(m.keys, key)
+				}
+				m.values[key] = val
+			}
+			_, err := dec.Token() // '}'
+			return m, err
+		case '[':
+			arr := []interface{}{}
+			for dec.More() {
+				val, err := decodeValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, val)
+			}
+			_, err := dec.Token() // ']'
+			return arr, err
+		}
+	}
+	return tok, nil
+}
+
+func toYAMLNode(v interface{}) *yaml.Node {
+	switch t := v.(type) {
+	case orderedMap:
+		n := &yaml.Node{Kind: yaml.MappingNode}
+		for _, k := range t.keys {
+			n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: k}, toYAMLNode(t.values[k]))
+		}
+		return n
+	case []interface{}:
+		n := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, item := range t {
+			n.Content = append(n.Content, toYAMLNode(item))
+		}
+		return n
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(t)}
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(t.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: t.String()}
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: t}
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(v)}
+}
+
+// fail reports a rendering error and exits, matching PrintJSON's contract.
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+	os.Exit(1)
+}
@@ -1,7 +1,6 @@
 package output
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -10,6 +9,7 @@ import (
 	"golang.org/x/term"
 )
 
+// JSONMode is set for every non-table output format (see Format)
 var JSONMode bool
 
 // ANSI codes — only used when stdout is a terminal
@@ -27,30 +27,42 @@ func init() {
 	}
 }
 
-// PrintJSON outputs data as formatted JSON
+// PrintJSON outputs data in the active format (formatted JSON by default).
+// json and yaml wrap it in an Envelope whose kind is named after v's type.
 func PrintJSON(v interface{}) {
-	data, err := json.MarshalIndent(v, "", "  ")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error formatting JSON: %v\n", err)
-		os.Exit(1)
-	}
-	fmt.Println(string(data))
+	PrintKind(KindOf(v), v)
+}
+
+// Table prints a formatted table with headers and rows. In structured formats
+// each row becomes an object keyed by the lowercased headers, keeping cell types.
+func Table(headers []string, rows []Row) {
+	table(headers, rows, nil)
 }
 
-// Table prints a formatted table with headers and rows
-func Table(headers []string, rows [][]string) {
+// table is Table with flex marking the free-text columns that may be
+// shortened to fit the terminal width.
+func table(headers []string, rows []Row, flex []bool) {
+	switch Format {
+	case FormatCSV, FormatTSV, FormatMarkdown:
+		if err := writeRows(os.Stdout, headers, cellStrings(rows)); err != nil {
+			fail(err)
+		}
+		return
+	}
 	if JSONMode {
-		var items []map[string]string
+		items := []orderedMap{}
 		for _, row := range rows {
-			item := make(map[string]string)
+			item := orderedMap{values: map[string]interface{}{}}
 			for i, h := range headers {
 				if i < len(row) {
-					item[strings.ToLower(h)] = row[i]
+					key := strings.ToLower(h)
+					item.keys = append(item.keys, key)
+					item.values[key] = row[i]
 				}
 			}
 			items = append(items, item)
 		}
-		PrintJSON(items)
+		PrintKind("Row", items)
 		return
 	}
 
@@ -75,12 +87,27 @@ func Table(headers []string, rows [][]string) {
 	}
 	fmt.Fprintln(w, strings.Join(sepParts, "\t"))
 
-	for _, row := range rows {
+	cells := cellStrings(rows)
+	if flex != nil {
+		fitWidth(headers, cells, flex, terminalWidth())
+	}
+	for _, row := range cells {
 		fmt.Fprintln(w, strings.Join(row, "\t"))
 	}
 	w.Flush()
 }
 
+func cellStrings(rows []Row) [][]string {
+	out := make([][]string, len(rows))
+	for i, row := range rows {
+		out[i] = make([]string, len(row))
+		for j, cell := range row {
+			out[i][j] = formatCell(cell)
+		}
+	}
+	return out
+}
+
 // Success prints a success message (suppressed in JSON mode)
 func Success(format string, args ...interface{}) {
 	if !JSONMode {
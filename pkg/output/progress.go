@@ -0,0 +1,238 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// Progress reports a long-running operation on stderr, never stdout. On a
+// terminal it redraws one status line (spinner, or a bar once a percentage is
+// known) with the elapsed time and prints a line per state transition. When
+// stderr is not a terminal it writes logfmt lines instead: one per state
+// change and a heartbeat every progressLogEvery.
+type Progress struct {
+	mu       sync.Mutex
+	out      io.Writer
+	tty      bool
+	title    string
+	state    string
+	fraction float64 // 0..1; <= 0 means unknown
+	start    time.Time
+	frame    int
+	drawn    bool // the status line is on screen
+	midLine  bool // wrapped output ended without a newline
+	stop     chan struct{}
+	done     bool
+}
+
+const (
+	progressTick     = 100 * time.Millisecond
+	progressLogEvery = 30 * time.Second
+	progressBarWidth = 20
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// StartProgress starts reporting on the operation named title. Call Done when
+// it ends.
+func StartProgress(title string) *Progress {
+	p := &Progress{
+		out:   os.Stderr,
+		tty:   term.IsTerminal(int(os.Stderr.Fd())),
+		title: title,
+		start: time.Now(),
+		stop:  make(chan struct{}),
+	}
+	p.mu.Lock()
+	if p.tty {
+		p.draw()
+	} else {
+		p.log("started", "")
+	}
+	p.mu.Unlock()
+	go p.run()
+	return p
+}
+
+func (p *Progress) run() {
+	interval := progressTick
+	if !p.tty {
+		interval = progressLogEvery
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-t.C:
+			p.mu.Lock()
+			if !p.done {
+				if p.tty {
+					p.frame++
+					p.draw()
+				} else {
+					p.log(p.state, "")
+				}
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+// Update sets the current state (e.g. RUNNING) and completed fraction, 0..1;
+// pass 0 when it is unknown.
+func (p *Progress) Update(state string, fraction float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done {
+		return
+	}
+	prev := p.state
+	p.state, p.fraction = state, fraction
+	if state == prev {
+		if p.tty {
+			p.draw()
+		}
+		return
+	}
+	if !p.tty {
+		p.log(state, "")
+		return
+	}
+	if prev != "" {
+		p.clear()
+		fmt.Fprintf(p.out, "  %s: %s → %s (%s)\n", p.title, prev, state, formatElapsed(time.Since(p.start)))
+	}
+	p.draw()
+}
+
+// Done stops reporting. err, if any, is only logged when stderr is not a
+// terminal; on a terminal the status line is cleared for the caller's message.
+// Done may be called more than once.
+func (p *Progress) Done(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done {
+		return
+	}
+	p.done = true
+	close(p.stop)
+	if p.tty {
+		p.clear()
+		return
+	}
+	if err != nil {
+		p.log("failed", err.Error())
+		return
+	}
+	if p.state == "" {
+		p.log("done", "") // otherwise the last state was just logged
+	}
+}
+
+// Wrap returns a writer for output produced while the operation runs (e.g. a
+// subprocess): the status line is cleared before each write and redrawn after.
+func (p *Progress) Wrap(w io.Writer) io.Writer {
+	return &progressWriter{p: p, w: w}
+}
+
+type progressWriter struct {
+	p *Progress
+	w io.Writer
+}
+
+func (pw *progressWriter) Write(b []byte) (int, error) {
+	p := pw.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.tty || p.done {
+		return pw.w.Write(b)
+	}
+	p.clear()
+	n, err := pw.w.Write(b)
+	p.midLine = len(b) > 0 && b[len(b)-1] != '\n'
+	p.draw()
+	return n, err
+}
+
+// draw redraws the status line; the caller holds p.mu.
+func (p *Progress) draw() {
+	if p.midLine {
+		return
+	}
+	var sb strings.Builder
+	sb.WriteString("  ")
+	if p.fraction > 0 {
+		filled := int(min(p.fraction, 1) * progressBarWidth)
+		sb.WriteString("[" + strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled) + "] ")
+		sb.WriteString(fmt.Sprintf("%3.0f%% ", min(p.fraction, 1)*100))
+	} else {
+		sb.WriteString(spinnerFrames[p.frame%len(spinnerFrames)] + " ")
+	}
+	sb.WriteString(p.title)
+	if p.state != "" {
+		sb.WriteString("  " + p.state)
+	}
+	sb.WriteString("  " + formatElapsed(time.Since(p.start)))
+
+	line := sb.String()
+	if w, _, err := term.GetSize(int(os.Stderr.Fd())); err == nil && w > 1 {
+		line = shorten(line, w-1) // a wrapped line can't be redrawn with \r
+	}
+	fmt.Fprint(p.out, "\r\033[K"+line)
+	p.drawn = true
+}
+
+// clear erases the status line; the caller holds p.mu.
+func (p *Progress) clear() {
+	if p.drawn {
+		fmt.Fprint(p.out, "\r\033[K")
+		p.drawn = false
+	}
+}
+
+// log writes one logfmt line; the caller holds p.mu.
+func (p *Progress) log(state, errMsg string) {
+	fields := []string{
+		"time=" + time.Now().UTC().Format(time.RFC3339),
+		"task=" + logfmtValue(p.title),
+	}
+	if state != "" {
+		fields = append(fields, "state="+logfmtValue(state))
+	}
+	if p.fraction > 0 {
+		fields = append(fields, fmt.Sprintf("progress=%.0f%%", min(p.fraction, 1)*100))
+	}
+	fields = append(fields, "elapsed="+formatElapsed(time.Since(p.start)))
+	if errMsg != "" {
+		fields = append(fields, "error="+logfmtValue(errMsg))
+	}
+	fmt.Fprintln(p.out, strings.Join(fields, " "))
+}
+
+func logfmtValue(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\"=\n") || !utf8.ValidString(s) {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+// formatElapsed prints d as 12s, 3m05s or 1h02m.
+func formatElapsed(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
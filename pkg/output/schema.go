@@ -0,0 +1,180 @@
+package output
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SchemaDraft is the JSON Schema dialect produced by EnvelopeSchema.
+const SchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	rawType     = reflect.TypeOf(json.RawMessage(nil))
+	numberType  = reflect.TypeOf(json.Number(""))
+	marshalType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// EnvelopeSchema returns the JSON Schema of the envelope PrintKind prints for
+// kind and a value of sample's type. Named structs are collected in defs.
+func EnvelopeSchema(kind string, sample interface{}, defs map[string]interface{}) map[string]interface{} {
+	t := reflect.TypeOf(sample)
+	props := map[string]interface{}{
+		"apiVersion": map[string]interface{}{"const": APIVersion},
+		"warnings":   map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+	}
+	required := []string{"apiVersion", "kind"}
+
+	switch {
+	case t == nil || t == rawType:
+		// Passed through from the server or a script: object or list
+		props["kind"] = map[string]interface{}{"enum": []string{kind, kind + "List"}}
+		props["item"] = map[string]interface{}{}
+		props["items"] = map[string]interface{}{"type": "array"}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		props["kind"] = map[string]interface{}{"const": kind + "List"}
+		props["items"] = map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), defs)}
+		required = append(required, "items")
+	default:
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem() // a nil result is printed as an empty object, not null
+		}
+		props["kind"] = map[string]interface{}{"const": kind}
+		props["item"] = typeSchema(t, defs)
+		required = append(required, "item")
+	}
+
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           props,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+// typeSchema follows encoding/json: field names and omitempty come from json
+// tags, embedded structs are inlined, and nil pointers, slices and maps
+// marshal as null.
+func typeSchema(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	switch t {
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case rawType:
+		return map[string]interface{}{}
+	case numberType:
+		return map[string]interface{}{"type": "number"}
+	}
+	if t.Kind() != reflect.Pointer && t.Implements(marshalType) {
+		// Custom encoding; the Go type says nothing about the JSON shape
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(typeSchema(t.Elem(), defs))
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+		}
+		return nullable(map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), defs)})
+	case reflect.Array:
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), defs)}
+	case reflect.Map:
+		return nullable(map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem(), defs)})
+	case reflect.Struct:
+		if t.Name() == "" {
+			return structSchema(t, defs)
+		}
+		name := kindOfType(t)
+		if _, ok := defs[name]; !ok {
+			defs[name] = nil // placeholder, so recursive types terminate
+			defs[name] = structSchema(t, defs)
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + name}
+	}
+	// interface{} and anything else: any JSON value
+	return map[string]interface{}{}
+}
+
+func structSchema(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	props := map[string]interface{}{}
+	var required []string
+	addFields(t, props, &required, defs)
+	s := map[string]interface{}{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func addFields(t reflect.Type, props map[string]interface{}, required *[]string, defs map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		if f.Anonymous && name == "" {
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addFields(ft, props, required, defs)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		var s map[string]interface{}
+		if hasOption(opts, "string") {
+			s = map[string]interface{}{"type": "string"}
+		} else {
+			s = typeSchema(ft, defs)
+		}
+		props[name] = s
+		if !hasOption(opts, "omitempty") && !hasOption(opts, "omitzero") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func hasOption(opts, name string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if o == name {
+			return true
+		}
+	}
+	return false
+}
+
+// nullable lets s also match null.
+func nullable(s map[string]interface{}) map[string]interface{} {
+	switch t := s["type"].(type) {
+	case string:
+		s["type"] = []string{t, "null"}
+		return s
+	case nil:
+		if len(s) == 0 {
+			return s // already any
+		}
+	}
+	return map[string]interface{}{"anyOf": []interface{}{s, map[string]interface{}{"type": "null"}}}
+}
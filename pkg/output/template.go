@@ -0,0 +1,616 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Template formats, selected with -o jsonpath=<expr> or -o go-template=<tmpl>
+const (
+	FormatJSONPath   = "jsonpath"
+	FormatGoTemplate = "go-template"
+)
+
+var (
+	jsonPath   *jpTemplate
+	goTemplate *template.Template
+)
+
+// setTemplate parses a jsonpath / go-template output spec. The -file variants
+// read the template from a path.
+func setTemplate(name, arg string) error {
+	if strings.HasSuffix(name, "-file") {
+		data, err := os.ReadFile(arg)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", name, err)
+		}
+		name = strings.TrimSuffix(name, "-file")
+		arg = string(data)
+	}
+
+	switch name {
+	case FormatJSONPath:
+		t, err := parseJSONPath(arg)
+		if err != nil {
+			return fmt.Errorf("parse jsonpath: %w", err)
+		}
+		jsonPath, Format = t, FormatJSONPath
+	case FormatGoTemplate:
+		t, err := template.New("output").Parse(arg)
+		if err != nil {
+			return fmt.Errorf("parse go-template: %w", err)
+		}
+		goTemplate, Format = t, FormatGoTemplate
+	default:
+		return fmt.Errorf("unknown output format %q (want jsonpath=<expr> or go-template=<tmpl>)", name)
+	}
+	return nil
+}
+
+// Templated reports whether output goes through a jsonpath or go-template.
+func Templated() bool {
+	return Format == FormatJSONPath || Format == FormatGoTemplate
+}
+
+// executeGoTemplate runs the go-template over the JSON form of the data, so
+// fields are addressed by their JSON names ({{.id}}, {{.name}}).
+func executeGoTemplate(w io.Writer, data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return err
+	}
+	return goTemplate.Execute(w, doc)
+}
+
+// --- JSONPath ---
+//
+// A kubectl-style subset: text with {expressions}, where an expression is a
+// path (.a.b, [0], [*], [1:3], ..name, ['key'], [?(@.x == "y")]), a quoted
+// literal ({"\n"}), or {range <path>} ... {end}. A top-level list is also
+// reachable as .items, as in kubectl.
+
+type jpTemplate struct {
+	nodes []jpNode
+}
+
+type jpNode struct {
+	text    string   // literal text, unless isPath or isRange
+	path    []jpStep // expression to print, or range subject
+	isPath  bool
+	isRange bool
+	body    []jpNode
+}
+
+type jpStepKind int
+
+const (
+	stepField jpStepKind = iota
+	stepRecursive
+	stepIndex
+	stepWildcard
+	stepSlice
+	stepFilter
+	stepRoot
+)
+
+type jpStep struct {
+	kind       jpStepKind
+	name       string
+	index      int
+	start, end *int
+	filter     *jpFilter
+}
+
+type jpFilter struct {
+	left  []jpStep
+	op    string // empty: existence check
+	right interface{}
+	rpath []jpStep
+}
+
+// jpList marks a top-level array so that .items resolves to it.
+type jpList []interface{}
+
+func parseJSONPath(src string) (*jpTemplate, error) {
+	root := &jpTemplate{}
+	stack := []*[]jpNode{&root.nodes}
+
+	for len(src) > 0 {
+		open := strings.IndexByte(src, '{')
+		if open < 0 {
+			*stack[len(stack)-1] = append(*stack[len(stack)-1], jpNode{text: src})
+			break
+		}
+		if open > 0 {
+			*stack[len(stack)-1] = append(*stack[len(stack)-1], jpNode{text: src[:open]})
+		}
+		close := matchClose(src, open, '{', '}')
+		if close < 0 {
+			return nil, fmt.Errorf("unclosed '{' in %q", src[open:])
+		}
+		expr := strings.TrimSpace(src[open+1 : close])
+		src = src[close+1:]
+
+		cur := stack[len(stack)-1]
+		switch {
+		case expr == "end":
+			if len(stack) == 1 {
+				return nil, fmt.Errorf("{end} without {range}")
+			}
+			stack = stack[:len(stack)-1]
+		case strings.HasPrefix(expr, "range "):
+			path, err := parsePath(strings.TrimSpace(strings.TrimPrefix(expr, "range ")))
+			if err != nil {
+				return nil, err
+			}
+			*cur = append(*cur, jpNode{path: path, isRange: true})
+			stack = append(stack, &(*cur)[len(*cur)-1].body)
+		case strings.HasPrefix(expr, `"`) || strings.HasPrefix(expr, "'"):
+			lit, err := unquoteLiteral(expr)
+			if err != nil {
+				return nil, err
+			}
+			*cur = append(*cur, jpNode{text: lit})
+		default:
+			path, err := parsePath(expr)
+			if err != nil {
+				return nil, err
+			}
+			*cur = append(*cur, jpNode{path: path, isPath: true})
+		}
+	}
+	if len(stack) > 1 {
+		return nil, fmt.Errorf("{range} without {end}")
+	}
+	return root, nil
+}
+
+// matchClose returns the index of the delimiter closing the one at open,
+// skipping quoted strings and nested pairs.
+func matchClose(s string, open int, left, right byte) int {
+	depth := 0
+	var quote byte
+	for i := open; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == left:
+			depth++
+		case c == right:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func unquoteLiteral(s string) (string, error) {
+	if strings.HasPrefix(s, "'") {
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return "", fmt.Errorf("bad literal %s", s)
+		}
+		s = `"` + strings.ReplaceAll(s[1:len(s)-1], `"`, `\"`) + `"`
+	}
+	out, err := strconv.Unquote(s)
+	if err != nil {
+		return "", fmt.Errorf("bad literal %s", s)
+	}
+	return out, nil
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func parsePath(s string) ([]jpStep, error) {
+	var steps []jpStep
+	i := 0
+	if strings.HasPrefix(s, "$") {
+		steps = append(steps, jpStep{kind: stepRoot})
+		i = 1
+	} else if strings.HasPrefix(s, "@") {
+		i = 1
+	}
+
+	ident := func() string {
+		start := i
+		for i < len(s) && isIdentChar(s[i]) {
+			i++
+		}
+		return s[start:i]
+	}
+
+	for i < len(s) {
+		switch {
+		case strings.HasPrefix(s[i:], ".."):
+			i += 2
+			if i < len(s) && s[i] == '*' {
+				i++
+				steps = append(steps, jpStep{kind: stepRecursive, name: "*"})
+				continue
+			}
+			name := ident()
+			if name == "" {
+				return nil, fmt.Errorf("expected field name after '..' in %q", s)
+			}
+			steps = append(steps, jpStep{kind: stepRecursive, name: name})
+		case s[i] == '.':
+			i++
+			if i < len(s) && s[i] == '*' {
+				i++
+				steps = append(steps, jpStep{kind: stepWildcard})
+				continue
+			}
+			if name := ident(); name != "" {
+				steps = append(steps, jpStep{kind: stepField, name: name})
+			}
+		case s[i] == '[':
+			close := matchClose(s, i, '[', ']')
+			if close < 0 {
+				return nil, fmt.Errorf("unclosed '[' in %q", s)
+			}
+			step, err := parseBracket(strings.TrimSpace(s[i+1 : close]))
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+			i = close + 1
+		default:
+			return nil, fmt.Errorf("unexpected %q in %q", s[i:], s)
+		}
+	}
+	return steps, nil
+}
+
+func parseBracket(inner string) (jpStep, error) {
+	switch {
+	case inner == "*":
+		return jpStep{kind: stepWildcard}, nil
+	case strings.HasPrefix(inner, "?(") && strings.HasSuffix(inner, ")"):
+		f, err := parseFilter(strings.TrimSpace(inner[2 : len(inner)-1]))
+		if err != nil {
+			return jpStep{}, err
+		}
+		return jpStep{kind: stepFilter, filter: f}, nil
+	case strings.HasPrefix(inner, "'") || strings.HasPrefix(inner, `"`):
+		name, err := unquoteLiteral(inner)
+		if err != nil {
+			return jpStep{}, err
+		}
+		return jpStep{kind: stepField, name: name}, nil
+	case strings.Contains(inner, ":"):
+		lo, hi, _ := strings.Cut(inner, ":")
+		step := jpStep{kind: stepSlice}
+		for _, b := range []struct {
+			s   string
+			dst **int
+		}{{lo, &step.start}, {hi, &step.end}} {
+			if b.s = strings.TrimSpace(b.s); b.s == "" {
+				continue
+			}
+			n, err := strconv.Atoi(b.s)
+			if err != nil {
+				return jpStep{}, fmt.Errorf("bad slice [%s]", inner)
+			}
+			*b.dst = &n
+		}
+		return step, nil
+	}
+	n, err := strconv.Atoi(inner)
+	if err != nil {
+		return jpStep{}, fmt.Errorf("bad index [%s]", inner)
+	}
+	return jpStep{kind: stepIndex, index: n}, nil
+}
+
+var filterOps = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+func parseFilter(s string) (*jpFilter, error) {
+	opAt, op := -1, ""
+	var quote byte
+	for i := 0; i < len(s) && opAt < 0; i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		if c == '"' || c == '\'' {
+			quote = c
+			continue
+		}
+		for _, candidate := range filterOps {
+			if strings.HasPrefix(s[i:], candidate) {
+				opAt, op = i, candidate
+				break
+			}
+		}
+	}
+
+	leftSrc := s
+	if opAt >= 0 {
+		leftSrc = strings.TrimSpace(s[:opAt])
+	}
+	left, err := parsePath(leftSrc)
+	if err != nil {
+		return nil, err
+	}
+	f := &jpFilter{left: left, op: op}
+	if op == "" {
+		return f, nil
+	}
+
+	rightSrc := strings.TrimSpace(s[opAt+len(op):])
+	switch {
+	case strings.HasPrefix(rightSrc, "@") || strings.HasPrefix(rightSrc, "$"):
+		f.rpath, err = parsePath(rightSrc)
+		return f, err
+	case strings.HasPrefix(rightSrc, "'") || strings.HasPrefix(rightSrc, `"`):
+		f.right, err = unquoteLiteral(rightSrc)
+		return f, err
+	case rightSrc == "true" || rightSrc == "false":
+		f.right = rightSrc == "true"
+	case rightSrc == "null":
+		f.right = nil
+	default:
+		if _, err := strconv.ParseFloat(rightSrc, 64); err != nil {
+			return nil, fmt.Errorf("bad filter value %q", rightSrc)
+		}
+		f.right = json.Number(rightSrc)
+	}
+	return f, nil
+}
+
+func (t *jpTemplate) execute(w io.Writer, doc interface{}) error {
+	if arr, ok := doc.([]interface{}); ok {
+		doc = jpList(arr)
+	}
+	var buf bytes.Buffer
+	writeNodes(&buf, t.nodes, doc, doc)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func writeNodes(buf *bytes.Buffer, nodes []jpNode, root, cur interface{}) {
+	for _, n := range nodes {
+		switch {
+		case n.isRange:
+			items := evalPath(n.path, root, cur)
+			if len(items) == 1 {
+				switch arr := items[0].(type) {
+				case []interface{}:
+					items = arr
+				case jpList:
+					items = arr
+				}
+			}
+			for _, item := range items {
+				writeNodes(buf, n.body, root, item)
+			}
+		case n.isPath:
+			for i, v := range evalPath(n.path, root, cur) {
+				if i > 0 {
+					buf.WriteByte(' ')
+				}
+				buf.WriteString(jpString(v))
+			}
+		default:
+			buf.WriteString(n.text)
+		}
+	}
+}
+
+func jpString(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case jpList:
+		v = []interface{}(t)
+	case orderedMap, []interface{}:
+	default:
+		return formatCell(v)
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func evalPath(steps []jpStep, root, cur interface{}) []interface{} {
+	values := []interface{}{cur}
+	for _, step := range steps {
+		var next []interface{}
+		for _, v := range values {
+			next = append(next, applyStep(step, root, v)...)
+		}
+		values = next
+	}
+	return values
+}
+
+func asArray(v interface{}) ([]interface{}, bool) {
+	switch t := v.(type) {
+	case []interface{}:
+		return t, true
+	case jpList:
+		return t, true
+	}
+	return nil, false
+}
+
+func applyStep(step jpStep, root, v interface{}) []interface{} {
+	switch step.kind {
+	case stepRoot:
+		return []interface{}{root}
+	case stepField:
+		if list, ok := v.(jpList); ok && step.name == "items" {
+			return []interface{}{[]interface{}(list)}
+		}
+		if m, ok := v.(orderedMap); ok {
+			if val, has := m.values[step.name]; has {
+				return []interface{}{val}
+			}
+		}
+	case stepWildcard:
+		if arr, ok := asArray(v); ok {
+			return arr
+		}
+		if m, ok := v.(orderedMap); ok {
+			out := make([]interface{}, 0, len(m.keys))
+			for _, k := range m.keys {
+				out = append(out, m.values[k])
+			}
+			return out
+		}
+	case stepIndex:
+		if arr, ok := asArray(v); ok {
+			i := step.index
+			if i < 0 {
+				i += len(arr)
+			}
+			if i >= 0 && i < len(arr) {
+				return []interface{}{arr[i]}
+			}
+		}
+	case stepSlice:
+		if arr, ok := asArray(v); ok {
+			lo, hi := 0, len(arr)
+			if step.start != nil {
+				lo = clampIndex(*step.start, len(arr))
+			}
+			if step.end != nil {
+				hi = clampIndex(*step.end, len(arr))
+			}
+			if lo < hi {
+				return arr[lo:hi]
+			}
+		}
+	case stepRecursive:
+		var out []interface{}
+		collect(v, step.name, &out)
+		return out
+	case stepFilter:
+		arr, ok := asArray(v)
+		if !ok {
+			return nil
+		}
+		var out []interface{}
+		for _, item := range arr {
+			if step.filter.match(root, item) {
+				out = append(out, item)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func clampIndex(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	return max(0, min(i, n))
+}
+
+// collect gathers, depth first, every value under v stored at key name
+// (or every descendant for "*").
+func collect(v interface{}, name string, out *[]interface{}) {
+	switch t := v.(type) {
+	case orderedMap:
+		for _, k := range t.keys {
+			if name == "*" || k == name {
+				*out = append(*out, t.values[k])
+			}
+			collect(t.values[k], name, out)
+		}
+	case []interface{}, jpList:
+		arr, _ := asArray(t)
+		for _, item := range arr {
+			if name == "*" {
+				*out = append(*out, item)
+			}
+			collect(item, name, out)
+		}
+	}
+}
+
+func (f *jpFilter) match(root, item interface{}) bool {
+	left := evalPath(f.left, root, item)
+	if f.op == "" {
+		return len(left) > 0 && left[0] != nil && left[0] != false
+	}
+	if len(left) == 0 {
+		return false
+	}
+	right := f.right
+	if f.rpath != nil {
+		r := evalPath(f.rpath, root, item)
+		if len(r) == 0 {
+			return false
+		}
+		right = r[0]
+	}
+	return compareValues(left[0], f.op, right)
+}
+
+func compareValues(a interface{}, op string, b interface{}) bool {
+	an, aNum := a.(json.Number)
+	bn, bNum := b.(json.Number)
+	if aNum && bNum {
+		x, _ := an.Float64()
+		y, _ := bn.Float64()
+		switch op {
+		case "==":
+			return x == y
+		case "!=":
+			return x != y
+		case "<":
+			return x < y
+		case "<=":
+			return x <= y
+		case ">":
+			return x > y
+		case ">=":
+			return x >= y
+		}
+	}
+	as, aStr := a.(string)
+	bs, bStr := b.(string)
+	if aStr && bStr {
+		switch op {
+		case "<":
+			return as < bs
+		case "<=":
+			return as <= bs
+		case ">":
+			return as > bs
+		case ">=":
+			return as >= bs
+		}
+	}
+	switch op {
+	case "==":
+		return jpString(a) == jpString(b) && (a == nil) == (b == nil)
+	case "!=":
+		return jpString(a) != jpString(b) || (a == nil) != (b == nil)
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const jpDoc = `{
+  "kind": "FeatureGroup",
+  "items": [
+    {"name": "transactions", "version": 2, "online": true, "features": [{"name": "id", "primary": true}, {"name": "amount"}]},
+    {"name": "customers", "version": 1, "online": false, "features": [{"name": "customer_id", "primary": true}]},
+    {"name": "products", "version": 10, "online": null, "owner": {"name": "ana"}}
+  ],
+  "meta": {"count": 3, "odd key": "x"}
+}`
+
+func runJSONPath(t *testing.T, expr, doc string) string {
+	t.Helper()
+	tmpl, err := parseJSONPath(expr)
+	if err != nil {
+		t.Fatalf("parse %q: %v", expr, err)
+	}
+	v, err := decodeOrdered([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.execute(&buf, v); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestJSONPath(t *testing.T) {
+	tests := []struct {
+		name, expr, want string
+	}{
+		{"field", "{.kind}", "FeatureGroup"},
+		{"root prefix", "{$.kind}", "FeatureGroup"},
+		{"nested", "{.meta.count}", "3"},
+		{"missing field", "{.nope}", ""},
+		{"quoted key", "{.meta['odd key']}", "x"},
+		{"double-quoted key", `{.meta["odd key"]}`, "x"},
+		{"index", "{.items[0].name}", "transactions"},
+		{"negative index", "{.items[-1].name}", "products"},
+		{"index out of range", "{.items[5].name}", ""},
+		{"wildcard", "{.items[*].name}", "transactions customers products"},
+		{"dot wildcard", "{.meta.*}", "3 x"},
+		{"slice", "{.items[0:2].name}", "transactions customers"},
+		{"open slice", "{.items[1:].name}", "customers products"},
+		{"negative slice", "{.items[-2:].version}", "1 10"},
+		{"empty slice", "{.items[2:1].name}", ""},
+		{"recursive", "{..primary}", "true true"},
+		{"recursive names", "{.items[2]..name}", "products ana"},
+		{"text around", "kind={.kind}!", "kind=FeatureGroup!"},
+		{"literal", `{.kind}{"\t"}{.meta.count}{"\n"}`, "FeatureGroup\t3\n"},
+		{"single-quoted literal", `{'a"b'}`, `a"b`},
+		{"object value", "{.items[2].owner}", `{"name":"ana"}`},
+		{"array value", "{.items[1].features[*].name}", "customer_id"},
+		{"null value", "{.items[2].online}", ""},
+		{"filter string", `{.items[?(@.name == "customers")].version}`, "1"},
+		{"filter number", "{.items[?(@.version > 1)].name}", "transactions products"},
+		{"filter number not string order", "{.items[?(@.version >= 10)].name}", "products"},
+		{"filter not equal", `{.items[?(@.name != 'products')].name}`, "transactions customers"},
+		{"filter bool", "{.items[?(@.online == true)].name}", "transactions"},
+		{"filter null", "{.items[?(@.online == null)].name}", "products"},
+		{"filter exists", "{.items[?(@.owner)].name}", "products"},
+		{"filter falsy", "{.items[?(@.online)].name}", "transactions"},
+		{"filter path operand", "{.items[?(@.version < $.meta.count)].name}", "transactions customers"},
+		{"filter nested path", "{.items[?(@.owner.name == 'ana')].version}", "10"},
+		{"range", "{range .items[*]}{.name}:{.version}{\"\\n\"}{end}", "transactions:2\ncustomers:1\nproducts:10\n"},
+		{"range over array", "{range .items}{.name},{end}", "transactions,customers,products,"},
+		{"nested range", "{range .items[0:2]}{.name}[{range .features[*]}{.name};{end}]{end}", "transactions[id;amount;]customers[customer_id;]"},
+		{"range sees root", "{range .items[0:1]}{$.kind}{end}", "FeatureGroup"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runJSONPath(t, tt.expr, jpDoc); got != tt.want {
+				t.Errorf("%s\n got %q\nwant %q", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONPathTopLevelList(t *testing.T) {
+	doc := `[{"id": 1}, {"id": 2}]`
+	tests := []struct{ expr, want string }{
+		{"{.items[*].id}", "1 2"},
+		{"{[*].id}", "1 2"},
+		{"{[1].id}", "2"},
+		{"{range .items[*]}{.id},{end}", "1,2,"},
+		{"{.items}", `[{"id":1},{"id":2}]`},
+	}
+	for _, tt := range tests {
+		if got := runJSONPath(t, tt.expr, doc); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestJSONPathParseErrors(t *testing.T) {
+	tests := []struct{ expr, want string }{
+		{"{.a", "unclosed '{'"},
+		{"{.a[0}", "unclosed '['"},
+		{"{range .items}{.a}", "{range} without {end}"},
+		{"{.a}{end}", "{end} without {range}"},
+		{"{.a[x]}", "bad index"},
+		{"{.a[1:x]}", "bad slice"},
+		{"{..}", "expected field name"},
+		{"{.a[?(@.b == foo)]}", "bad filter value"},
+		{`{"unterminated}`, "unclosed '{'"},
+		{"{'a}", "unclosed '{'"},
+		{`{"\q"}`, "bad literal"},
+		{"{a}", "unexpected"},
+	}
+	for _, tt := range tests {
+		_, err := parseJSONPath(tt.expr)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("parseJSONPath(%q) error = %v, want it to contain %q", tt.expr, err, tt.want)
+		}
+	}
+}
+
+func TestMatchClose(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"{a}", 2},
+		{"{a{b}c}d", 6},
+		{`{"}"}`, 4},
+		{`{'\''}`, 5},
+		{"{a", -1},
+	}
+	for _, tt := range tests {
+		if got := matchClose(tt.s, 0, '{', '}'); got != tt.want {
+			t.Errorf("matchClose(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestGoTemplate(t *testing.T) {
+	defer SetFormat(FormatTable)
+	tests := []struct{ tmpl, want string }{
+		{"{{.kind}}", "FeatureGroup"},
+		{"{{range .items}}{{.name}}={{.version}} {{end}}", "transactions=2 customers=1 products=10 "},
+		{"{{(index .items 2).owner.name}}", "ana"},
+		{"{{.meta.count}}", "3"},
+	}
+	for _, tt := range tests {
+		if err := setTemplate(FormatGoTemplate, tt.tmpl); err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := executeGoTemplate(&buf, []byte(jpDoc)); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.tmpl, got, tt.want)
+		}
+	}
+	if err := setTemplate(FormatGoTemplate, "{{.a"); err == nil {
+		t.Error("want a parse error for an unclosed action")
+	}
+}